@@ -16,4 +16,9 @@ type Config struct {
 		AccessSecret string
 		//AccessExpire int64
 	}
+
+	// 管理接口（例如广播系统公告）的令牌，未配置时拒绝所有管理请求
+	Admin struct {
+		Token string `json:",optional"`
+	}
 }
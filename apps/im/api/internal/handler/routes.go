@@ -44,8 +44,44 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/conversation",
 				Handler: putConversationsHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/sync",
+				Handler: syncMessagesHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.JwtAuth.AccessSecret),
+		rest.WithPrefix("/v1/im"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/announcements",
+				Handler: getAnnouncementsHandler(serverCtx),
+			},
 		},
 		rest.WithJwt(serverCtx.Config.JwtAuth.AccessSecret),
 		rest.WithPrefix("/v1/im"),
 	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AdminMiddleware},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/broadcast",
+					Handler: broadcastHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/broadcast/stats",
+					Handler: getBroadcastStatsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/v1/im/admin"),
+	)
 }
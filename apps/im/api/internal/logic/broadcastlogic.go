@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"context"
+	"im-chat/easy-chat/apps/im/rpc/imclient"
+	"im-chat/easy-chat/pkg/constants"
+
+	"im-chat/easy-chat/apps/im/api/internal/svc"
+	"im-chat/easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type BroadcastLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewBroadcastLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BroadcastLogic {
+	return &BroadcastLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Broadcast 以系统用户的身份广播系统公告，仅供管理员调用。
+//
+// 参数:
+//   - req: 公告的内容及投递范围，segment 为 0 时发送给所有用户，为 1 时发送给 groupId 群的成员，为 2 时发送给 version 版本的用户。
+//
+// 返回值:
+//   - *types.BroadcastResp: 公告的 ID 及投递的 im.ws 节点数。
+//   - error: 参数有误或发布失败时返回错误。
+func (l *BroadcastLogic) Broadcast(req *types.BroadcastReq) (resp *types.BroadcastResp, err error) {
+	data, err := l.svcCtx.Im.Broadcast(l.ctx, &imclient.BroadcastReq{
+		SendId:   constants.SYSTEM_ROOT_UID,
+		Title:    req.Title,
+		Content:  req.Content,
+		Segment:  req.Segment,
+		GroupId:  req.GroupId,
+		Version:  req.Version,
+		ExpireAt: req.ExpireAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BroadcastResp{
+		Id:    data.Id,
+		Nodes: data.Nodes,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"context"
+	"github.com/jinzhu/copier"
+	"im-chat/easy-chat/apps/im/rpc/imclient"
+	"im-chat/easy-chat/apps/social/rpc/socialclient"
+	"im-chat/easy-chat/pkg/ctxdata"
+
+	"im-chat/easy-chat/apps/im/api/internal/svc"
+	"im-chat/easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetAnnouncementsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetAnnouncementsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetAnnouncementsLogic {
+	return &GetAnnouncementsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetAnnouncements 获取当前用户可以接收的系统公告。
+//
+// 用户离线期间错过的广播通过该接口拉取，返回发送给所有用户、用户所在的群及用户客户端版本的公告。
+// 客户端记录拉取到的最新公告的发送时间，下次拉取时作为 sendTime 传入，只返回之后发送的公告。
+//
+// 参数:
+//   - req: 客户端版本、上次拉取的发送时间及数量。
+//
+// 返回值:
+//   - *types.GetAnnouncementsResp: 公告列表，按发送时间倒序。
+//   - error: 查询失败时返回错误。
+func (l *GetAnnouncementsLogic) GetAnnouncements(req *types.GetAnnouncementsReq) (resp *types.GetAnnouncementsResp, err error) {
+	uid := ctxdata.GetUId(l.ctx)
+
+	groups, err := l.svcCtx.GroupList(l.ctx, &socialclient.GroupListReq{
+		UserId: uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	groupIds := make([]string, 0, len(groups.List))
+	for _, group := range groups.List {
+		groupIds = append(groupIds, group.Id)
+	}
+
+	data, err := l.svcCtx.Im.GetAnnouncements(l.ctx, &imclient.GetAnnouncementsReq{
+		GroupIds: groupIds,
+		Version:  req.Version,
+		SendTime: req.SendTime,
+		Count:    req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.GetAnnouncementsResp
+	copier.Copy(&res, &data)
+
+	return &res, nil
+}
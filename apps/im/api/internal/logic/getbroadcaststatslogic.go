@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+	"github.com/jinzhu/copier"
+	"im-chat/easy-chat/apps/im/rpc/imclient"
+
+	"im-chat/easy-chat/apps/im/api/internal/svc"
+	"im-chat/easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetBroadcastStatsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetBroadcastStatsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetBroadcastStatsLogic {
+	return &GetBroadcastStatsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetBroadcastStats 获取系统公告的投递统计，仅供管理员调用。
+//
+// 参数:
+//   - req: 公告的 ID。
+//
+// 返回值:
+//   - *types.GetBroadcastStatsResp: 公告、投递及完成广播的节点数、送达及失败的连接数。
+//   - error: 公告不存在或查询失败时返回错误。
+func (l *GetBroadcastStatsLogic) GetBroadcastStats(req *types.GetBroadcastStatsReq) (resp *types.GetBroadcastStatsResp, err error) {
+	data, err := l.svcCtx.Im.GetBroadcastStats(l.ctx, &imclient.GetBroadcastStatsReq{
+		Id: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.GetBroadcastStatsResp
+	copier.Copy(&res, &data)
+
+	return &res, nil
+}
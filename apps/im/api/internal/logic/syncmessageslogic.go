@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"context"
+	"github.com/jinzhu/copier"
+	"im-chat/easy-chat/apps/im/rpc/imclient"
+	"im-chat/easy-chat/pkg/ctxdata"
+
+	"im-chat/easy-chat/apps/im/api/internal/svc"
+	"im-chat/easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncMessagesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSyncMessagesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncMessagesLogic {
+	return &SyncMessagesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SyncMessages 按消息序号同步当前用户所有会话中缺失的消息。
+//
+// 客户端重连后提交各会话已收到的最新序号，一次请求即可取回离线期间的消息；
+// hasMore 为 true 时以返回的 cursor 作为 seqs 继续请求。
+//
+// 参数:
+//   - req: 各会话已收到的最新序号及每页的消息数。
+//
+// 返回值:
+//   - *types.SyncMessagesResp: 缺失的消息、同步到的序号及是否还有更多消息。
+//   - error: 查询失败时返回错误。
+func (l *SyncMessagesLogic) SyncMessages(req *types.SyncMessagesReq) (resp *types.SyncMessagesResp, err error) {
+	uid := ctxdata.GetUId(l.ctx)
+
+	data, err := l.svcCtx.Im.SyncMessages(l.ctx, &imclient.SyncMessagesReq{
+		UserId: uid,
+		Seqs:   req.Seqs,
+		Count:  req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.SyncMessagesResp
+	copier.Copy(&res, &data)
+
+	return &res, nil
+}
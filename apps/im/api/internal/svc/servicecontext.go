@@ -1,16 +1,20 @@
 package svc
 
 import (
+	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
 	"im-chat/easy-chat/apps/im/api/internal/config"
 	"im-chat/easy-chat/apps/im/rpc/imclient"
 	"im-chat/easy-chat/apps/social/rpc/socialclient"
 	"im-chat/easy-chat/apps/user/rpc/userclient"
+	"im-chat/easy-chat/pkg/middleware"
 )
 
 type ServiceContext struct {
 	Config config.Config
 
+	AdminMiddleware rest.Middleware
+
 	imclient.Im
 	userclient.User
 	socialclient.Social
@@ -18,7 +22,8 @@ type ServiceContext struct {
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	return &ServiceContext{
-		Config: c,
+		Config:          c,
+		AdminMiddleware: middleware.NewAdminMiddleware(c.Admin.Token).Handler,
 
 		Im:     imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
 		User:   userclient.NewUser(zrpc.MustNewClient(c.UserRpc)),
@@ -10,6 +10,8 @@ type ChatLog struct {
 	MsgContent     string `json:"msgContent,omitempty"`
 	ChatType       int32  `json:"chatType,omitempty"`
 	SendTime       int64  `json:"SendTime,omitempty"`
+	Seq            int64  `json:"seq,omitempty"`
+	ClientMsgId    string `json:"clientMsgId,omitempty"`
 }
 
 type Conversation struct {
@@ -66,3 +68,62 @@ type SetUpUserConversationReq struct {
 
 type SetUpUserConversationResp struct {
 }
+
+type SyncMessagesReq struct {
+	Seqs  map[string]int64 `json:"seqs,optional"`
+	Count int64            `json:"count,optional"`
+}
+
+type SyncMessagesResp struct {
+	List    []*ChatLog       `json:"list"`
+	Cursor  map[string]int64 `json:"cursor"`
+	HasMore bool             `json:"hasMore"`
+}
+
+type Announcement struct {
+	Id       string `json:"id"`
+	SendId   string `json:"sendId,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Content  string `json:"content"`
+	Segment  int32  `json:"segment"`
+	GroupId  string `json:"groupId,omitempty"`
+	Version  string `json:"version,omitempty"`
+	SendTime int64  `json:"sendTime"`
+	ExpireAt int64  `json:"expireAt,omitempty"`
+}
+
+type GetAnnouncementsReq struct {
+	Version  string `form:"version,optional"`
+	SendTime int64  `form:"sendTime,optional"`
+	Count    int64  `form:"count,optional"`
+}
+
+type GetAnnouncementsResp struct {
+	List []*Announcement `json:"list"`
+}
+
+type BroadcastReq struct {
+	Title    string `json:"title,optional"`
+	Content  string `json:"content"`
+	Segment  int32  `json:"segment,optional"`
+	GroupId  string `json:"groupId,optional"`
+	Version  string `json:"version,optional"`
+	ExpireAt int64  `json:"expireAt,optional"`
+}
+
+type BroadcastResp struct {
+	Id    string `json:"id"`
+	Nodes int32  `json:"nodes"`
+}
+
+type GetBroadcastStatsReq struct {
+	Id string `form:"id"`
+}
+
+type GetBroadcastStatsResp struct {
+	Announcement *Announcement `json:"announcement"`
+	Targets      int64         `json:"targets"`
+	Nodes        int64         `json:"nodes"`
+	Delivered    int64         `json:"delivered"`
+	Failed       int64         `json:"failed"`
+}
@@ -0,0 +1,29 @@
+package immodels
+
+import "github.com/zeromicro/go-zero/core/stores/mon"
+
+var _ AnnouncementModel = (*customAnnouncementModel)(nil)
+
+type (
+	// AnnouncementModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customAnnouncementModel.
+	AnnouncementModel interface {
+		announcementModel
+	}
+
+	customAnnouncementModel struct {
+		*defaultAnnouncementModel
+	}
+)
+
+// NewAnnouncementModel returns a model for the mongo.
+func NewAnnouncementModel(url, db, collection string) AnnouncementModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &customAnnouncementModel{
+		defaultAnnouncementModel: newDefaultAnnouncementModel(conn),
+	}
+}
+
+func MustAnnouncementModel(url, db string) AnnouncementModel {
+	return NewAnnouncementModel(url, db, "announcement")
+}
@@ -0,0 +1,28 @@
+package immodels
+
+import (
+	"im-chat/easy-chat/pkg/constants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var DefaultAnnouncementLimit int64 = 50
+
+// Announcement 系统公告，发送时在线的用户通过 im.ws 实时接收，离线的用户上线后拉取
+type Announcement struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+
+	SendId   string                     `bson:"sendId"`
+	Title    string                     `bson:"title"`
+	Content  string                     `bson:"content"`
+	Segment  constants.BroadcastSegment `bson:"segment"`
+	GroupId  string                     `bson:"groupId,omitempty"`
+	Version  string                     `bson:"version,omitempty"`
+	SendTime int64                      `bson:"sendTime"`
+	// 过期时间（Unix 毫秒），过期后不再返回给拉取的用户，为 0 时不过期
+	ExpireAt int64 `bson:"expireAt"`
+
+	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
@@ -15,6 +15,7 @@ type chatLogModel interface {
 	Insert(ctx context.Context, data *ChatLog) error
 	FindOne(ctx context.Context, id string) (*ChatLog, error)
 	ListBySendTime(ctx context.Context, conversationId string, startSendTime, endSendTime, limit int64) ([]*ChatLog, error)
+	ListBySeq(ctx context.Context, conversationId string, seq, limit int64) ([]*ChatLog, error)
 	Update(ctx context.Context, data *ChatLog) error
 	Delete(ctx context.Context, id string) error
 	ListByIds(ctx context.Context, msgIds []string) ([]*ChatLog, error)
@@ -147,3 +148,34 @@ func (m *defaultChatLogModel) ListBySendTime(ctx context.Context, conversationId
 		return nil, err
 	}
 }
+
+// 查询会话中序号大于 seq 的聊天记录，按序号升序
+func (m *defaultChatLogModel) ListBySeq(ctx context.Context, conversationId string, seq, limit int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	opt := options.FindOptions{
+		Limit: &DefaultChatLogLimit,
+		Sort: bson.M{
+			"seq": 1,
+		},
+	}
+	if limit > 0 {
+		opt.Limit = &limit
+	}
+
+	filter := bson.M{
+		"conversationId": conversationId,
+		"seq": bson.M{
+			"$gt": seq,
+		},
+	}
+	err := m.conn.Find(ctx, &data, filter, &opt)
+	switch err {
+	case nil:
+		return data, nil
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
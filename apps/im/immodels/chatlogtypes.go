@@ -14,6 +14,7 @@ type ChatLog struct {
 
 	ConversationId string             `bson:"conversationId"`
 	SendId         string             `bson:"sendId"`
+	ClientMsgId    string             `bson:"clientMsgId,omitempty"`
 	RecvId         string             `bson:"recvId"`
 	MsgFrom        int                `bson:"msgFrom"`
 	ChatType       constants.ChatType `bson:"chatType"`
@@ -22,6 +23,8 @@ type ChatLog struct {
 	SendTime       int64              `bson:"sendTime"`
 	Status         int                `bson:"status"`
 	ReadRecords    []byte             `bson:"readRecords"`
+	// 会话内单调递增的消息序号，由 task.mq 分配，客户端据此发现缺失的消息并排序
+	Seq int64 `bson:"seq"`
 
 	// TODO: Fill your own fields
 	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
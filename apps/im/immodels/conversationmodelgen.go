@@ -3,11 +3,13 @@ package immodels
 
 import (
 	"context"
+	"im-chat/easy-chat/pkg/constants"
 	"time"
 
 	"github.com/zeromicro/go-zero/core/stores/mon"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type conversationModel interface {
@@ -17,6 +19,7 @@ type conversationModel interface {
 	Delete(ctx context.Context, id string) error
 	ListByConversationIds(ctx context.Context, ids []string) ([]*Conversation, error)
 	UpdateMsg(ctx context.Context, chatLog *ChatLog) error
+	IncrSeq(ctx context.Context, conversationId string, chatType constants.ChatType, msgId string) (int64, error)
 }
 
 type defaultConversationModel struct {
@@ -85,9 +88,17 @@ func (m *defaultConversationModel) ListByConversationIds(ctx context.Context, id
 		return nil, err
 	}
 }
+
+// 更新会话的最后一条消息，只有消息的序号大于会话当前的最后一条消息时才更新，重复更新同一条消息不会重复计数
 func (m *defaultConversationModel) UpdateMsg(ctx context.Context, chatLog *ChatLog) error {
 	_, err := m.conn.UpdateOne(ctx,
-		bson.M{"conversationId": chatLog.ConversationId},
+		bson.M{
+			"conversationId": chatLog.ConversationId,
+			"$or": bson.A{
+				bson.M{"msg.seq": bson.M{"$lt": chatLog.Seq}},
+				bson.M{"msg.seq": bson.M{"$exists": false}},
+			},
+		},
 		bson.M{
 			// 更新会话总消息数
 			"$inc": bson.M{"total": 1},
@@ -96,3 +107,56 @@ func (m *defaultConversationModel) UpdateMsg(ctx context.Context, chatLog *ChatL
 	)
 	return err
 }
+
+// 原子地为消息分配会话的下一个消息序号，会话不存在时创建。
+// 会话最近一次分配的序号属于 msgId 时（分配后写入聊天记录失败，重试同一条消息）返回已分配的序号，不再递增
+func (m *defaultConversationModel) IncrSeq(ctx context.Context, conversationId string, chatType constants.ChatType,
+	msgId string) (int64, error) {
+	var data Conversation
+
+	err := m.conn.FindOneAndUpdate(ctx, &data,
+		bson.M{"conversationId": conversationId, "seqMsgId": bson.M{"$ne": msgId}},
+		bson.M{
+			"$inc": bson.M{"seq": 1},
+			"$set": bson.M{"seqMsgId": msgId},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	switch err {
+	case nil:
+		return data.Seq, nil
+	case mon.ErrNotFound:
+	default:
+		return 0, err
+	}
+
+	// 会话不存在，或序号已经分配给该消息
+	err = m.conn.FindOne(ctx, &data, bson.M{"conversationId": conversationId})
+	switch err {
+	case nil:
+		if data.SeqMsgId == msgId {
+			return data.Seq, nil
+		}
+		return 0, ErrSeqConflict
+	case mon.ErrNotFound:
+	default:
+		return 0, err
+	}
+
+	err = m.conn.FindOneAndUpdate(ctx, &data,
+		bson.M{"conversationId": conversationId},
+		bson.M{
+			"$inc": bson.M{"seq": 1},
+			"$set": bson.M{"seqMsgId": msgId},
+			"$setOnInsert": bson.M{
+				"chatType": chatType,
+				"createAt": time.Now(),
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return data.Seq, nil
+}
@@ -18,6 +18,9 @@ type Conversation struct {
 	Seq    int64    `bson:"seq"`
 	Msg    *ChatLog `bson:"msg,omitempty"`
 
+	// 最近一次分配序号的消息ID，写入聊天记录失败后重试时复用已分配的序号
+	SeqMsgId string `bson:"seqMsgId,omitempty"`
+
 	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
 	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
 }
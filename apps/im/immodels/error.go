@@ -9,4 +9,6 @@ import (
 var (
 	ErrNotFound        = mon.ErrNotFound
 	ErrInvalidObjectId = errors.New("invalid objectId")
+	// 会话的序号在分配时被其他消息抢先分配，稍后重试
+	ErrSeqConflict = errors.New("conversation seq conflict")
 )
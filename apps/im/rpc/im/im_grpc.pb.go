@@ -31,6 +31,14 @@ type ImClient interface {
 	// 更新会话
 	PutConversations(ctx context.Context, in *PutConversationsReq, opts ...grpc.CallOption) (*PutConversationsResp, error)
 	CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error)
+	// 广播系统公告
+	Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error)
+	// 获取用户可以接收的系统公告
+	GetAnnouncements(ctx context.Context, in *GetAnnouncementsReq, opts ...grpc.CallOption) (*GetAnnouncementsResp, error)
+	// 获取系统公告的投递统计
+	GetBroadcastStats(ctx context.Context, in *GetBroadcastStatsReq, opts ...grpc.CallOption) (*GetBroadcastStatsResp, error)
+	// 按消息序号同步用户所有会话中缺失的消息
+	SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error)
 }
 
 type imClient struct {
@@ -86,6 +94,42 @@ func (c *imClient) CreateGroupConversation(ctx context.Context, in *CreateGroupC
 	return out, nil
 }
 
+func (c *imClient) Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error) {
+	out := new(BroadcastResp)
+	err := c.cc.Invoke(ctx, "/im.Im/Broadcast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) GetAnnouncements(ctx context.Context, in *GetAnnouncementsReq, opts ...grpc.CallOption) (*GetAnnouncementsResp, error) {
+	out := new(GetAnnouncementsResp)
+	err := c.cc.Invoke(ctx, "/im.Im/GetAnnouncements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) GetBroadcastStats(ctx context.Context, in *GetBroadcastStatsReq, opts ...grpc.CallOption) (*GetBroadcastStatsResp, error) {
+	out := new(GetBroadcastStatsResp)
+	err := c.cc.Invoke(ctx, "/im.Im/GetBroadcastStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error) {
+	out := new(SyncMessagesResp)
+	err := c.cc.Invoke(ctx, "/im.Im/SyncMessages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ImServer is the server API for Im service.
 // All implementations must embed UnimplementedImServer
 // for forward compatibility
@@ -99,6 +143,14 @@ type ImServer interface {
 	// 更新会话
 	PutConversations(context.Context, *PutConversationsReq) (*PutConversationsResp, error)
 	CreateGroupConversation(context.Context, *CreateGroupConversationReq) (*CreateGroupConversationResp, error)
+	// 广播系统公告
+	Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error)
+	// 获取用户可以接收的系统公告
+	GetAnnouncements(context.Context, *GetAnnouncementsReq) (*GetAnnouncementsResp, error)
+	// 获取系统公告的投递统计
+	GetBroadcastStats(context.Context, *GetBroadcastStatsReq) (*GetBroadcastStatsResp, error)
+	// 按消息序号同步用户所有会话中缺失的消息
+	SyncMessages(context.Context, *SyncMessagesReq) (*SyncMessagesResp, error)
 	mustEmbedUnimplementedImServer()
 }
 
@@ -121,6 +173,18 @@ func (UnimplementedImServer) PutConversations(context.Context, *PutConversations
 func (UnimplementedImServer) CreateGroupConversation(context.Context, *CreateGroupConversationReq) (*CreateGroupConversationResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateGroupConversation not implemented")
 }
+func (UnimplementedImServer) Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (UnimplementedImServer) GetAnnouncements(context.Context, *GetAnnouncementsReq) (*GetAnnouncementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnnouncements not implemented")
+}
+func (UnimplementedImServer) GetBroadcastStats(context.Context, *GetBroadcastStatsReq) (*GetBroadcastStatsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcastStats not implemented")
+}
+func (UnimplementedImServer) SyncMessages(context.Context, *SyncMessagesReq) (*SyncMessagesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncMessages not implemented")
+}
 func (UnimplementedImServer) mustEmbedUnimplementedImServer() {}
 
 // UnsafeImServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +288,78 @@ func _Im_CreateGroupConversation_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Im_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/Broadcast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).Broadcast(ctx, req.(*BroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_GetAnnouncements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnnouncementsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).GetAnnouncements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/GetAnnouncements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).GetAnnouncements(ctx, req.(*GetAnnouncementsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_GetBroadcastStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBroadcastStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).GetBroadcastStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/GetBroadcastStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).GetBroadcastStats(ctx, req.(*GetBroadcastStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_SyncMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncMessagesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).SyncMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/SyncMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).SyncMessages(ctx, req.(*SyncMessagesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Im_ServiceDesc is the grpc.ServiceDesc for Im service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +387,22 @@ var Im_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateGroupConversation",
 			Handler:    _Im_CreateGroupConversation_Handler,
 		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _Im_Broadcast_Handler,
+		},
+		{
+			MethodName: "GetAnnouncements",
+			Handler:    _Im_GetAnnouncements_Handler,
+		},
+		{
+			MethodName: "GetBroadcastStats",
+			Handler:    _Im_GetBroadcastStats_Handler,
+		},
+		{
+			MethodName: "SyncMessages",
+			Handler:    _Im_SyncMessages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "apps/im/rpc/im.proto",
@@ -13,10 +13,17 @@ import (
 )
 
 type (
+	Announcement                = im.Announcement
+	BroadcastReq                = im.BroadcastReq
+	BroadcastResp               = im.BroadcastResp
 	ChatLog                     = im.ChatLog
 	Conversation                = im.Conversation
 	CreateGroupConversationReq  = im.CreateGroupConversationReq
 	CreateGroupConversationResp = im.CreateGroupConversationResp
+	GetAnnouncementsReq         = im.GetAnnouncementsReq
+	GetAnnouncementsResp        = im.GetAnnouncementsResp
+	GetBroadcastStatsReq        = im.GetBroadcastStatsReq
+	GetBroadcastStatsResp       = im.GetBroadcastStatsResp
 	GetChatLogReq               = im.GetChatLogReq
 	GetChatLogResp              = im.GetChatLogResp
 	GetConversationsReq         = im.GetConversationsReq
@@ -25,6 +32,8 @@ type (
 	PutConversationsResp        = im.PutConversationsResp
 	SetUpUserConversationReq    = im.SetUpUserConversationReq
 	SetUpUserConversationResp   = im.SetUpUserConversationResp
+	SyncMessagesReq             = im.SyncMessagesReq
+	SyncMessagesResp            = im.SyncMessagesResp
 
 	Im interface {
 		//  获取会话记录
@@ -36,6 +45,14 @@ type (
 		//  更新会话
 		PutConversations(ctx context.Context, in *PutConversationsReq, opts ...grpc.CallOption) (*PutConversationsResp, error)
 		CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error)
+		//  广播系统公告
+		Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error)
+		//  获取用户可以接收的系统公告
+		GetAnnouncements(ctx context.Context, in *GetAnnouncementsReq, opts ...grpc.CallOption) (*GetAnnouncementsResp, error)
+		//  获取系统公告的投递统计
+		GetBroadcastStats(ctx context.Context, in *GetBroadcastStatsReq, opts ...grpc.CallOption) (*GetBroadcastStatsResp, error)
+		//  按消息序号同步用户所有会话中缺失的消息
+		SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error)
 	}
 
 	defaultIm struct {
@@ -77,3 +94,27 @@ func (m *defaultIm) CreateGroupConversation(ctx context.Context, in *CreateGroup
 	client := im.NewImClient(m.cli.Conn())
 	return client.CreateGroupConversation(ctx, in, opts...)
 }
+
+// 广播系统公告
+func (m *defaultIm) Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.Broadcast(ctx, in, opts...)
+}
+
+// 获取用户可以接收的系统公告
+func (m *defaultIm) GetAnnouncements(ctx context.Context, in *GetAnnouncementsReq, opts ...grpc.CallOption) (*GetAnnouncementsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.GetAnnouncements(ctx, in, opts...)
+}
+
+// 获取系统公告的投递统计
+func (m *defaultIm) GetBroadcastStats(ctx context.Context, in *GetBroadcastStatsReq, opts ...grpc.CallOption) (*GetBroadcastStatsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.GetBroadcastStats(ctx, in, opts...)
+}
+
+// 按消息序号同步用户所有会话中缺失的消息
+func (m *defaultIm) SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.SyncMessages(ctx, in, opts...)
+}
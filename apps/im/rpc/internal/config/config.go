@@ -1,6 +1,9 @@
 package config
 
-import "github.com/zeromicro/go-zero/zrpc"
+import (
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
+)
 
 type Config struct {
 	zrpc.RpcServerConf
@@ -9,4 +12,9 @@ type Config struct {
 		Url string
 		Db  string
 	}
+
+	// 系统公告通过 im.ws 节点广播，节点记录及系统用户的令牌保存在 Redis 中
+	Redisx redis.RedisConf
+	// im.ws 使用 wss 时开启
+	WsTLS bool `json:",optional"`
 }
@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"im-chat/easy-chat/apps/im/immodels"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/pkg/constants"
+	"im-chat/easy-chat/pkg/xerr"
+	"strconv"
+	"time"
+
+	"im-chat/easy-chat/apps/im/rpc/im"
+	"im-chat/easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 系统公告投递统计的保留时间（秒）
+const broadcastStatsExpire = 7 * 24 * 3600
+
+type BroadcastLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewBroadcastLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BroadcastLogic {
+	return &BroadcastLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Broadcast 广播系统公告。
+//
+// 公告先保存到数据库，离线的用户上线后通过 GetAnnouncements 拉取；然后投递到所有存活的 im.ws 节点，
+// 由各个节点分批向投递范围内的连接广播，并在 Redis 中累计送达的连接数。
+// 投递到节点失败不影响公告的发布，失败的节点上的用户只能通过拉取获得公告。
+//
+// 参数:
+//   - in: 公告的内容及投递范围。
+//
+// 返回:
+//   - *im.BroadcastResp: 公告的 ID 及投递的节点数。
+//   - error: 参数有误或保存公告失败时返回错误。
+func (l *BroadcastLogic) Broadcast(in *im.BroadcastReq) (*im.BroadcastResp, error) {
+	segment := constants.BroadcastSegment(in.Segment)
+	switch {
+	case in.Content == "",
+		segment == constants.BroadcastGroup && in.GroupId == "",
+		segment == constants.BroadcastVersion && in.Version == "",
+		segment < constants.BroadcastAll || segment > constants.BroadcastVersion:
+		return nil, errors.WithStack(xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR)))
+	}
+
+	announcement := &immodels.Announcement{
+		SendId:   in.SendId,
+		Title:    in.Title,
+		Content:  in.Content,
+		Segment:  segment,
+		GroupId:  in.GroupId,
+		Version:  in.Version,
+		SendTime: time.Now().UnixMilli(),
+		ExpireAt: in.ExpireAt,
+	}
+	if err := l.svcCtx.AnnouncementModel.Insert(l.ctx, announcement); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "AnnouncementModel.Insert err %v, req %v", err, in)
+	}
+	id := announcement.ID.Hex()
+
+	data := &ws.Broadcast{
+		Id:               id,
+		SendId:           announcement.SendId,
+		Title:            announcement.Title,
+		Content:          announcement.Content,
+		BroadcastSegment: announcement.Segment,
+		GroupId:          announcement.GroupId,
+		Version:          announcement.Version,
+		SendTime:         announcement.SendTime,
+		ExpireAt:         announcement.ExpireAt,
+	}
+	nodes, err := l.svcCtx.Dispatcher.Broadcast(func(node string) any {
+		return websocket.Message{
+			FrameType: websocket.FrameData,
+			Method:    "push.broadcast",
+			FormId:    constants.SYSTEM_ROOT_UID,
+			Data:      data,
+		}
+	})
+	if err != nil {
+		l.Errorf("broadcast announcement %v err %v", id, err)
+	}
+
+	// 记录需要完成广播的节点数，各节点完成后累计送达的连接数
+	key := fmt.Sprintf("%s:%s", constants.REDIS_BROADCAST_STATS, id)
+	if err := l.svcCtx.Hset(key, "targets", strconv.Itoa(nodes)); err != nil {
+		l.Errorf("set broadcast %v stats err %v", id, err)
+	} else if err := l.svcCtx.Expire(key, broadcastStatsExpire); err != nil {
+		l.Errorf("expire broadcast %v stats err %v", id, err)
+	}
+
+	return &im.BroadcastResp{
+		Id:    id,
+		Nodes: int32(nodes),
+	}, nil
+}
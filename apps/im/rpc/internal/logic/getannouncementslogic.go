@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"im-chat/easy-chat/apps/im/immodels"
+	"im-chat/easy-chat/pkg/xerr"
+
+	"im-chat/easy-chat/apps/im/rpc/im"
+	"im-chat/easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetAnnouncementsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetAnnouncementsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetAnnouncementsLogic {
+	return &GetAnnouncementsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetAnnouncements 获取用户可以接收的系统公告。
+//
+// 用于离线期间错过广播的用户拉取公告，返回发送给所有用户、用户所在的群及用户客户端版本的未过期公告，按发送时间倒序。
+//
+// 参数:
+//   - in: 用户所在的群、客户端版本，以及上次拉取到的最新公告的发送时间。
+//
+// 返回:
+//   - *im.GetAnnouncementsResp: 公告列表。
+//   - error: 查询失败时返回错误。
+func (l *GetAnnouncementsLogic) GetAnnouncements(in *im.GetAnnouncementsReq) (*im.GetAnnouncementsResp, error) {
+	data, err := l.svcCtx.AnnouncementModel.ListBySegment(l.ctx, in.GroupIds, in.Version, in.SendTime, in.Count)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "AnnouncementModel.ListBySegment err %v, req %v", err, in)
+	}
+
+	res := make([]*im.Announcement, 0, len(data))
+	for _, datum := range data {
+		res = append(res, toAnnouncement(datum))
+	}
+	return &im.GetAnnouncementsResp{
+		List: res,
+	}, nil
+}
+
+func toAnnouncement(data *immodels.Announcement) *im.Announcement {
+	return &im.Announcement{
+		Id:       data.ID.Hex(),
+		SendId:   data.SendId,
+		Title:    data.Title,
+		Content:  data.Content,
+		Segment:  int32(data.Segment),
+		GroupId:  data.GroupId,
+		Version:  data.Version,
+		SendTime: data.SendTime,
+		ExpireAt: data.ExpireAt,
+	}
+}
@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"im-chat/easy-chat/pkg/constants"
+	"im-chat/easy-chat/pkg/xerr"
+	"strconv"
+
+	"im-chat/easy-chat/apps/im/rpc/im"
+	"im-chat/easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetBroadcastStatsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetBroadcastStatsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetBroadcastStatsLogic {
+	return &GetBroadcastStatsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetBroadcastStats 获取系统公告的投递统计。
+//
+// 统计由各个 im.ws 节点完成广播后累计，nodes 小于 targets 时表示仍有节点在广播或节点投递失败。
+// 统计保留 7 天，过期后只返回公告本身。
+//
+// 参数:
+//   - in: 公告的 ID。
+//
+// 返回:
+//   - *im.GetBroadcastStatsResp: 公告及投递的节点数、送达及失败的连接数。
+//   - error: 公告不存在或查询失败时返回错误。
+func (l *GetBroadcastStatsLogic) GetBroadcastStats(in *im.GetBroadcastStatsReq) (*im.GetBroadcastStatsResp, error) {
+	announcement, err := l.svcCtx.AnnouncementModel.FindOne(l.ctx, in.Id)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "AnnouncementModel.FindOne err %v, req %v", err, in.Id)
+	}
+
+	stats, err := l.svcCtx.Hgetall(fmt.Sprintf("%s:%s", constants.REDIS_BROADCAST_STATS, in.Id))
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "get broadcast stats err %v, req %v", err, in.Id)
+	}
+
+	count := func(field string) int64 {
+		n, _ := strconv.ParseInt(stats[field], 10, 64)
+		return n
+	}
+	return &im.GetBroadcastStatsResp{
+		Announcement: toAnnouncement(announcement),
+		Targets:      count("targets"),
+		Nodes:        count("nodes"),
+		Delivered:    count("delivered"),
+		Failed:       count("failed"),
+	}, nil
+}
@@ -58,6 +58,8 @@ func (l *GetChatLogLogic) GetChatLog(in *im.GetChatLogReq) (*im.GetChatLogResp,
 					MsgContent:     chatLog.MsgContent,
 					ChatType:       int32(chatLog.ChatType),
 					SendTime:       chatLog.SendTime,
+					Seq:            chatLog.Seq,
+					ClientMsgId:    chatLog.ClientMsgId,
 				},
 			},
 		}, nil
@@ -79,6 +81,8 @@ func (l *GetChatLogLogic) GetChatLog(in *im.GetChatLogReq) (*im.GetChatLogResp,
 			MsgContent:     datum.MsgContent,
 			ChatType:       int32(datum.ChatType),
 			SendTime:       datum.SendTime,
+			Seq:            datum.Seq,
+			ClientMsgId:    datum.ClientMsgId,
 		})
 	}
 
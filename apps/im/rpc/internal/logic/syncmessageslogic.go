@@ -0,0 +1,124 @@
+package logic
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"im-chat/easy-chat/apps/im/immodels"
+	"im-chat/easy-chat/pkg/xerr"
+	"sort"
+
+	"im-chat/easy-chat/apps/im/rpc/im"
+	"im-chat/easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// maxSyncCount 单页同步的消息数上限
+const maxSyncCount = 500
+
+type SyncMessagesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSyncMessagesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncMessagesLogic {
+	return &SyncMessagesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// SyncMessages 按消息序号同步用户所有会话中缺失的消息。
+//
+// 客户端重连后提交各会话已收到的最新序号，先根据会话当前的序号筛选出有新消息的会话，
+// 再按会话ID的顺序逐个拉取序号更大的消息，直到填满一页。返回的 cursor 记录每个会话同步到的序号，
+// HasMore 为 true 时客户端以 cursor 作为 seqs 继续请求下一页。
+//
+// 参数:
+//   - in: 用户ID、各会话已收到的最新序号及每页的消息数。
+//
+// 返回:
+//   - *im.SyncMessagesResp: 缺失的消息、同步到的序号及是否还有更多消息。
+//   - error: 查询失败时返回错误。
+func (l *SyncMessagesLogic) SyncMessages(in *im.SyncMessagesReq) (*im.SyncMessagesResp, error) {
+	limit := in.Count
+	if limit <= 0 {
+		limit = immodels.DefaultChatLogLimit
+	}
+	limit = min(limit, maxSyncCount)
+
+	// 只同步用户自己的会话
+	data, err := l.svcCtx.ConversationsModel.FindByUserId(l.ctx, in.UserId)
+	if err != nil {
+		if err == immodels.ErrNotFound {
+			return &im.SyncMessagesResp{}, nil
+		}
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationsModel.FindByUserId err %v, req %v", err, in.UserId)
+	}
+
+	ids := make([]string, 0, len(data.ConversationList))
+	for _, conversation := range data.ConversationList {
+		ids = append(ids, conversation.ConversationId)
+	}
+	// 固定会话的顺序，使分页的结果稳定
+	sort.Strings(ids)
+
+	conversations, err := l.svcCtx.ConversationModel.ListByConversationIds(l.ctx, ids)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.ListByConversationIds err %v, req %v", err, ids)
+	}
+	latest := make(map[string]int64, len(conversations))
+	for _, conversation := range conversations {
+		latest[conversation.ConversationId] = conversation.Seq
+	}
+
+	res := &im.SyncMessagesResp{
+		Cursor: make(map[string]int64, len(ids)),
+	}
+	for _, id := range ids {
+		seq := in.Seqs[id]
+		res.Cursor[id] = seq
+		if latest[id] <= seq {
+			continue
+		}
+
+		remaining := limit - int64(len(res.List))
+		if remaining <= 0 {
+			res.HasMore = true
+			continue
+		}
+
+		chatLogs, err := l.svcCtx.ChatLogModel.ListBySeq(l.ctx, id, seq, remaining)
+		if err != nil && err != immodels.ErrNotFound {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "ChatLogModel.ListBySeq err %v, conversationId %v, seq %v", err, id, seq)
+		}
+		for _, chatLog := range chatLogs {
+			res.List = append(res.List, toChatLog(chatLog))
+			res.Cursor[id] = chatLog.Seq
+		}
+
+		// 返回的消息不足一页说明会话已同步完，序号的空洞不会导致无限翻页
+		if int64(len(chatLogs)) == remaining && res.Cursor[id] < latest[id] {
+			res.HasMore = true
+		}
+	}
+
+	return res, nil
+}
+
+func toChatLog(data *immodels.ChatLog) *im.ChatLog {
+	return &im.ChatLog{
+		Id:             data.ID.Hex(),
+		ConversationId: data.ConversationId,
+		SendId:         data.SendId,
+		RecvId:         data.RecvId,
+		MsgType:        int32(data.MsgType),
+		MsgContent:     data.MsgContent,
+		ChatType:       int32(data.ChatType),
+		SendTime:       data.SendTime,
+		Seq:            data.Seq,
+		ClientMsgId:    data.ClientMsgId,
+	}
+}
@@ -50,3 +50,27 @@ func (s *ImServer) CreateGroupConversation(ctx context.Context, in *im.CreateGro
 	l := logic.NewCreateGroupConversationLogic(ctx, s.svcCtx)
 	return l.CreateGroupConversation(in)
 }
+
+// 广播系统公告
+func (s *ImServer) Broadcast(ctx context.Context, in *im.BroadcastReq) (*im.BroadcastResp, error) {
+	l := logic.NewBroadcastLogic(ctx, s.svcCtx)
+	return l.Broadcast(in)
+}
+
+// 获取用户可以接收的系统公告
+func (s *ImServer) GetAnnouncements(ctx context.Context, in *im.GetAnnouncementsReq) (*im.GetAnnouncementsResp, error) {
+	l := logic.NewGetAnnouncementsLogic(ctx, s.svcCtx)
+	return l.GetAnnouncements(in)
+}
+
+// 获取系统公告的投递统计
+func (s *ImServer) GetBroadcastStats(ctx context.Context, in *im.GetBroadcastStatsReq) (*im.GetBroadcastStatsResp, error) {
+	l := logic.NewGetBroadcastStatsLogic(ctx, s.svcCtx)
+	return l.GetBroadcastStats(in)
+}
+
+// 按消息序号同步用户所有会话中缺失的消息
+func (s *ImServer) SyncMessages(ctx context.Context, in *im.SyncMessagesReq) (*im.SyncMessagesResp, error) {
+	l := logic.NewSyncMessagesLogic(ctx, s.svcCtx)
+	return l.SyncMessages(in)
+}
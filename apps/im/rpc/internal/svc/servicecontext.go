@@ -1,24 +1,73 @@
 package svc
 
 import (
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"im-chat/easy-chat/apps/im/immodels"
 	"im-chat/easy-chat/apps/im/rpc/internal/config"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/pkg/constants"
+	"net/http"
 )
 
 type ServiceContext struct {
 	Config config.Config
 
+	*redis.Redis
+
 	immodels.ChatLogModel
 	immodels.ConversationsModel
 	immodels.ConversationModel
+	immodels.AnnouncementModel
+
+	// 将系统公告投递到所有 im.ws 节点
+	Dispatcher *websocket.Dispatcher
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
-	return &ServiceContext{
+	svc := &ServiceContext{
 		Config: c,
+		Redis:  redis.MustNewRedis(c.Redisx),
 
 		ChatLogModel:       immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
 		ConversationsModel: immodels.MustConversationsModel(c.Mongo.Url, c.Mongo.Db),
 		ConversationModel:  immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
+		AnnouncementModel:  immodels.MustAnnouncementModel(c.Mongo.Url, c.Mongo.Db),
+	}
+
+	// 启动时系统令牌必须存在，之后每次连接 im.ws 时重新读取，令牌轮换后重连即使用新的令牌
+	if _, err := svc.GetSystemToken(); err != nil {
+		panic(err)
+	}
+
+	opts := []websocket.DailOptions{websocket.WithClientHeaderFunc(svc.systemHeader)}
+	if c.WsTLS {
+		opts = append(opts, websocket.WithClientTLS(nil))
+	}
+	svc.Dispatcher = websocket.NewDispatcher(websocket.NewRedisLocator(svc.Redis, 0), opts...)
+	return svc
+}
+
+// GetSystemToken 获取系统用户的令牌，令牌不存在时返回错误。
+func (svc *ServiceContext) GetSystemToken() (string, error) {
+	token, err := svc.Redis.Get(constants.REDIS_SYSTEM_ROOT_TOKEN)
+	if err != nil {
+		return "", err
 	}
+	if token == "" {
+		return "", errors.Errorf("system token %v not found", constants.REDIS_SYSTEM_ROOT_TOKEN)
+	}
+	return token, nil
+}
+
+// systemHeader 连接 im.ws 时使用系统用户的令牌认证。
+func (svc *ServiceContext) systemHeader() (http.Header, error) {
+	token, err := svc.GetSystemToken()
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", token)
+	return header, nil
 }
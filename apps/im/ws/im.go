@@ -3,11 +3,16 @@ package main
 import (
 	"flag"
 	"fmt"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/core/prometheus"
 	"im-chat/easy-chat/apps/im/ws/internal/config"
 	"im-chat/easy-chat/apps/im/ws/internal/handler"
 	"im-chat/easy-chat/apps/im/ws/internal/svc"
 	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/pkg/constants"
+	"net/http"
 )
 
 var configFile = flag.String("f", "etc/dev/im.yaml", "the config file")
@@ -25,13 +30,34 @@ func main() {
 	ctx := svc.NewServiceContext(c)
 	srv := websocket.NewServer(c.ListenOn,
 		websocket.WithServerAuthentication(handler.NewJwtAuth(ctx)),
+		websocket.WithServerLocator(ctx.Locator, c.AdvertiseAddr),
+		websocket.WithServerInternalUsers(constants.SYSTEM_ROOT_UID),
+		websocket.WithServerPresence(ctx.Presence),
+		websocket.WithServerOfflineStore(websocket.NewRedisOfflineStore(ctx.Redis, 0)),
+		websocket.WithServerTLS(c.CertFile, c.KeyFile),
+		websocket.WithServerAllowOrigins(c.AllowOrigins...),
+		websocket.WithServerFallback(c.Fallback),
+		websocket.WithServerAdmin(c.Admin.Path, c.Admin.Token),
+		websocket.WithServerConcurrency(c.Broadcast.Concurrency),
+		websocket.WithServerBroadcastBatch(c.Broadcast.Batch),
 		//websocket.WithServerAck(websocket.RigorAck),
 		//websocket.WithServerMaxConnectionIdle(10*time.Second),
 	)
 	defer srv.Stop()
+	// 收到退出信号时通知客户端重连并等待消息处理完成
+	proc.AddShutdownListener(srv.Stop)
+
+	srv.Use(websocket.RecoverMiddleware, websocket.LogMiddleware, websocket.TimingMiddleware(0),
+		websocket.NewRateLimiter(c.RateLimit, ctx.Redis).Middleware)
 
 	//加载路由
 	handler.RegisterHandlers(srv, ctx)
+	// 健康检查及监控指标与 websocket 共用监听地址
+	srv.Handle("/healthz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	prometheus.Enable()
+	srv.Handle(c.MetricsPath, promhttp.Handler())
 
 	fmt.Println("start websocket server at ", c.ListenOn, " ..... ")
 	srv.Start()
@@ -1,16 +1,43 @@
 package config
 
-import "github.com/zeromicro/go-zero/core/service"
+import (
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"time"
+)
 
 type Config struct {
 	service.ServiceConf
 
 	ListenOn string
+	// 节点对外的访问地址，用于集群间的消息路由，默认使用 ListenOn
+	AdvertiseAddr string `json:",optional"`
+	// wss 使用的证书及私钥，未配置时使用 ws
+	CertFile string `json:",optional"`
+	KeyFile  string `json:",optional"`
+	// 允许连接的浏览器来源，未配置时允许所有来源
+	AllowOrigins []string `json:",optional"`
+	// HTTP 回退（SSE、长轮询）的路由前缀，供无法建立 websocket 连接的客户端使用，未配置时不开启
+	Fallback string `json:",optional"`
+	// 监控指标的路径，与 websocket 共用监听地址
+	MetricsPath string `json:",default=/metrics"`
+	// 管理接口，与 websocket 共用监听地址，未配置 Token 时不开启
+	Admin struct {
+		Path  string `json:",default=/admin"`
+		Token string `json:",optional"`
+	}
+
+	Redisx redis.RedisConf
 
 	JwtAuth struct {
 		AccessSecret string
 	}
 
+	// 消息限流，未配置时不限制
+	RateLimit websocket.RateLimitConf `json:",optional"`
+
 	Mongo struct {
 		Url string
 		Db  string
@@ -25,4 +52,29 @@ type Config struct {
 		Topic string
 		Addrs []string
 	}
+
+	SocialRpc zrpc.RpcClientConf
+
+	// 会话事件（例如正在输入）的节流间隔及有效期
+	ConversationEvent struct {
+		Throttle time.Duration `json:",default=1s"`
+		TTL      time.Duration `json:",default=5s"`
+	}
+
+	// 聊天消息的去重窗口，窗口内客户端使用相同的 clientMsgId 重发的消息不会重复处理
+	ChatDedup struct {
+		Window time.Duration `json:",default=1h"`
+	}
+
+	// 在线状态的通知，连接断开后在 Debounce 内重新连接不会通知，成员超过 MaxGroupSize 的群不通知群成员
+	PresenceEvent struct {
+		Debounce     time.Duration `json:",default=3s"`
+		MaxGroupSize int           `json:",default=200"`
+	}
+
+	// 系统公告的广播，每批向 Batch 个连接发送，同时发送 Concurrency 批
+	Broadcast struct {
+		Batch       int `json:",default=500"`
+		Concurrency int `json:",default=10"`
+	}
 }
@@ -2,14 +2,24 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"github.com/golang-jwt/jwt/v4"
+	ws "github.com/gorilla/websocket"
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest/token"
 	"im-chat/easy-chat/apps/im/ws/internal/svc"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/pkg/constants"
 	"im-chat/easy-chat/pkg/ctxdata"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// tokenInfoKey 请求上下文中保存令牌信息的键。
+type tokenInfoKey struct{}
+
 // JwtAuth 用于处理基于 JWT 的身份认证。
 //
 // 该结构体包含了服务上下文、令牌解析器和日志记录器，用于验证 WebSocket 请求的 JWT 令牌，
@@ -39,7 +49,7 @@ func NewJwtAuth(svc *svc.ServiceContext) *JwtAuth {
 
 // Auth 验证请求的 JWT 令牌。
 //
-// 该方法从请求头中提取 JWT 令牌，并使用解析器进行验证。如果令牌有效，
+// 该方法从请求头或子协议中提取 JWT 令牌，并使用解析器进行验证。如果令牌有效，
 // 将用户标识符注入到请求的上下文中。
 //
 // 参数:
@@ -50,8 +60,12 @@ func NewJwtAuth(svc *svc.ServiceContext) *JwtAuth {
 //   - bool: 如果验证成功返回 true，否则返回 false。
 func (j *JwtAuth) Auth(w http.ResponseWriter, r *http.Request) bool {
 
-	if token := r.Header.Get("sec-websocket-protocol"); token != "" {
-		r.Header.Set("Authorization", token)
+	// 子协议中除编解码器以外的部分为令牌
+	for _, protocol := range ws.Subprotocols(r) {
+		if websocket.GetCodec(protocol) == nil {
+			r.Header.Set("Authorization", protocol)
+			break
+		}
 	}
 
 	tok, err := j.parser.ParseToken(r, j.svc.Config.JwtAuth.AccessSecret, "")
@@ -69,7 +83,14 @@ func (j *JwtAuth) Auth(w http.ResponseWriter, r *http.Request) bool {
 		return false
 	}
 
-	*r = *r.WithContext(context.WithValue(r.Context(), ctxdata.Identify, claims[ctxdata.Identify]))
+	info := tokenInfo(claims)
+	if j.Revoked(info.Uid, info.IssuedAt) {
+		j.Infof("token revoked uid %v", info.Uid)
+		return false
+	}
+
+	ctx := context.WithValue(r.Context(), ctxdata.Identify, claims[ctxdata.Identify])
+	*r = *r.WithContext(context.WithValue(ctx, tokenInfoKey{}, info))
 
 	return true
 }
@@ -86,3 +107,88 @@ func (j *JwtAuth) Auth(w http.ResponseWriter, r *http.Request) bool {
 func (j *JwtAuth) UserId(r *http.Request) string {
 	return ctxdata.GetUId(r.Context())
 }
+
+// Token 从请求的上下文中获取 Auth 验证通过的令牌信息。
+//
+// 参数:
+//   - r: HTTP 请求对象，其中包含了请求的所有信息。
+//
+// 返回值:
+//   - websocket.TokenInfo: 令牌对应的用户、签发时间及过期时间。
+func (j *JwtAuth) Token(r *http.Request) websocket.TokenInfo {
+	info, _ := r.Context().Value(tokenInfoKey{}).(websocket.TokenInfo)
+	return info
+}
+
+// Refresh 验证客户端在连接内提交的新令牌。
+//
+// 参数:
+//   - tokenString: 客户端提交的 JWT 令牌。
+//
+// 返回值:
+//   - websocket.TokenInfo: 新令牌的信息。
+//   - error: 令牌无效时返回错误，被吊销时返回 websocket.ErrTokenRevoked。
+func (j *JwtAuth) Refresh(tokenString string) (websocket.TokenInfo, error) {
+	tok, err := jwt.Parse(tokenString, func(t *jwt.Token) (any, error) {
+		// 令牌使用 HMAC 签发，拒绝其他签名算法
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(j.svc.Config.JwtAuth.AccessSecret), nil
+	})
+	if err != nil {
+		return websocket.TokenInfo{}, err
+	}
+
+	claims, ok := tok.Claims.(jwt.MapClaims)
+	if !ok || !tok.Valid {
+		return websocket.TokenInfo{}, fmt.Errorf("invalid token")
+	}
+
+	info := tokenInfo(claims)
+	if j.Revoked(info.Uid, info.IssuedAt) {
+		return websocket.TokenInfo{}, websocket.ErrTokenRevoked
+	}
+	return info, nil
+}
+
+// Revoked 判断用户在 issuedAt 签发的令牌是否已被吊销。
+//
+// 用户登出（user.rpc 的 Logout）时，在 Redis 的 constants.REDIS_TOKEN_REVOKED 中记录吊销的时间（Unix 秒），
+// 在此之前签发的令牌均视为已吊销，重新登录获取的令牌不受影响。
+//
+// 参数:
+//   - uid: 用户标识符。
+//   - issuedAt: 令牌的签发时间。
+//
+// 返回值:
+//   - bool: 令牌是否已被吊销，查询失败时视为未吊销。
+func (j *JwtAuth) Revoked(uid string, issuedAt time.Time) bool {
+	val, err := j.svc.Hget(constants.REDIS_TOKEN_REVOKED, uid)
+	if err != nil {
+		if err != redis.Nil {
+			j.Errorf("get token revoked uid %v err %v", uid, err)
+		}
+		return false
+	}
+
+	revokedAt, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return false
+	}
+	return issuedAt.Unix() <= revokedAt
+}
+
+// tokenInfo 从令牌的声明中获取令牌信息。
+func tokenInfo(claims jwt.MapClaims) websocket.TokenInfo {
+	info := websocket.TokenInfo{
+		Uid: fmt.Sprint(claims[ctxdata.Identify]),
+	}
+	if iat, ok := claims["iat"].(float64); ok {
+		info.IssuedAt = time.Unix(int64(iat), 0)
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		info.ExpireAt = time.Unix(int64(exp), 0)
+	}
+	return info
+}
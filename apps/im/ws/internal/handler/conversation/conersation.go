@@ -1,13 +1,14 @@
 package conversation
 
 import (
-	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"im-chat/easy-chat/apps/im/ws/internal/svc"
 	"im-chat/easy-chat/apps/im/ws/websocket"
 	"im-chat/easy-chat/apps/im/ws/ws"
 	"im-chat/easy-chat/apps/task/mq/mq"
 	"im-chat/easy-chat/pkg/constants"
 	"im-chat/easy-chat/pkg/wuid"
+	"im-chat/easy-chat/pkg/xerr"
 	"time"
 )
 
@@ -15,8 +16,10 @@ import (
 //
 // 该函数返回一个 websocket.HandlerFunc 处理函数，用于接收并处理聊天消息。
 // 它将 WebSocket 消息解码为 ws.Chat 结构体，若消息未指定会话ID，则根据聊天类型生成会话ID。
-// 处理完成后，将聊天消息推送到消息聊天传输客户端进行处理。
-// 如果解码或消息处理失败，将通过 WebSocket 向客户端发送错误信息。
+// 处理完成后，为消息分配服务端的消息ID，并将聊天消息推送到消息聊天传输客户端进行处理，
+// 推送成功后向客户端返回 FrameResult 结果，包含分配的消息ID及客户端的消息ID。
+// 同一发送者在去重窗口内使用相同的客户端消息ID重发的消息不会再次投递，直接返回首次发送的结果。
+// 如果解码或消息处理失败，将通过 WebSocket 向客户端发送带有错误码的错误信息。
 //
 // 参数:
 //   - svc: 包含服务上下文的 *svc.ServiceContext，用于访问消息聊天传输客户端。
@@ -27,8 +30,8 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
 		// todo: 私聊
 		var data ws.Chat
-		if err := mapstructure.Decode(msg.Data, &data); err != nil {
-			srv.Send(websocket.NewErrMessage(err), conn)
+		if err := conn.Bind(msg, &data); err != nil {
+			srv.Send(websocket.NewErrReply(msg, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))), conn)
 			return
 		}
 
@@ -42,20 +45,54 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 
 		}
 
+		// 服务端分配的消息ID，同时作为聊天记录的ID
+		res := &ws.ChatResult{
+			ConversationId: data.ConversationId,
+			MsgId:          primitive.NewObjectID().Hex(),
+			ClientMsgId:    data.ClientMsgId,
+			SendTime:       time.Now().UnixMilli(),
+		}
+		// 未携带客户端消息ID时使用请求帧的ID，客户端重发时保持不变
+		if res.ClientMsgId == "" {
+			res.ClientMsgId = msg.Id
+		}
+
+		// 去重窗口内重复发送的消息不再投递，返回首次发送时的结果
+		if res.ClientMsgId != "" {
+			first, err := acquireChat(svc, conn.Uid, res)
+			if err != nil {
+				srv.Errorf("acquire chat dedup uid %v msg %v err %v", conn.Uid, res.ClientMsgId, err)
+			}
+			if first != nil {
+				srv.Send(websocket.NewResultMessage(msg, first), conn)
+				return
+			}
+		}
+
 		err := svc.MsgChatTransferClient.Push(&mq.MsgChatTransfer{
 			ConversationId: data.ConversationId,
 			ChatType:       data.ChatType,
 			SendId:         conn.Uid,
+			SendDeviceId:   conn.DeviceId,
 			RecvId:         data.RecvId,
-			SendTime:       time.Now().UnixMilli(),
+			SendTime:       res.SendTime,
 			MType:          data.Msg.MType,
 			Content:        data.Msg.Content,
-			MsgId:          msg.Id,
+			MsgId:          res.ClientMsgId,
+			ServerMsgId:    res.MsgId,
 		})
 		if err != nil {
-			srv.Send(websocket.NewErrMessage(err), conn)
+			srv.Errorf("push msg chat transfer uid %v err %v", conn.Uid, err)
+			if res.ClientMsgId != "" {
+				if err := releaseChat(svc, conn.Uid, res.ClientMsgId); err != nil {
+					srv.Errorf("release chat dedup uid %v msg %v err %v", conn.Uid, res.ClientMsgId, err)
+				}
+			}
+			srv.Send(websocket.NewErrReply(msg, xerr.NewInternalErr()), conn)
 			return
 		}
+
+		srv.Send(websocket.NewResultMessage(msg, res), conn)
 		//err := logic.NewConversation(context.Background(), srv, svc).SingleChat(&data, conn.Uid)
 		//if err != nil {
 		//	srv.Send(websocket.NewErrMessage(err), conn)
@@ -77,8 +114,8 @@ func MarkRead(svc *svc.ServiceContext) websocket.HandlerFunc {
 	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
 		// todo: 已读未读处理
 		var data ws.MarkRead
-		if err := mapstructure.Decode(msg.Data, &data); err != nil {
-			srv.Send(websocket.NewErrMessage(err), conn)
+		if err := conn.Bind(msg, &data); err != nil {
+			srv.Send(websocket.NewErrReply(msg, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))), conn)
 			return
 		}
 
@@ -90,9 +127,12 @@ func MarkRead(svc *svc.ServiceContext) websocket.HandlerFunc {
 			MsgIds:         data.MsgIds,
 		})
 		if err != nil {
-			srv.Send(websocket.NewErrMessage(err), conn)
+			srv.Errorf("push msg read transfer uid %v err %v", conn.Uid, err)
+			srv.Send(websocket.NewErrReply(msg, xerr.NewInternalErr()), conn)
 			return
 		}
 
+		srv.Send(websocket.NewResultMessage(msg, nil), conn)
+
 	}
 }
@@ -0,0 +1,54 @@
+package conversation
+
+import (
+	"fmt"
+	"im-chat/easy-chat/apps/im/ws/internal/svc"
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/pkg/constants"
+	"strconv"
+	"strings"
+)
+
+func chatDedupKey(sendId, clientMsgId string) string {
+	return fmt.Sprintf("%s:%s:%s", constants.REDIS_CHAT_DEDUP, sendId, clientMsgId)
+}
+
+// acquireChat 在去重窗口内记录客户端消息ID对应的发送结果。
+//
+// 首次发送时记录 res 并返回 nil；窗口内重复发送时返回首次发送的结果，Duplicate 为 true。
+//
+// 参数:
+//   - svc: 服务上下文。
+//   - sendId: 发送者ID。
+//   - res: 本次发送分配的结果，ClientMsgId 不能为空。
+//
+// 返回:
+//   - *ws.ChatResult: 首次发送的结果，首次发送时为 nil。
+//   - error: 读写去重记录失败时返回错误。
+func acquireChat(svc *svc.ServiceContext, sendId string, res *ws.ChatResult) (*ws.ChatResult, error) {
+	key := chatDedupKey(sendId, res.ClientMsgId)
+	value := fmt.Sprintf("%s:%d", res.MsgId, res.SendTime)
+	ok, err := svc.SetnxEx(key, value, int(svc.Config.ChatDedup.Window.Seconds()))
+	if err != nil || ok {
+		return nil, err
+	}
+
+	exist, err := svc.Get(key)
+	if err != nil || exist == "" {
+		// 记录恰好过期时按首次发送处理
+		return nil, err
+	}
+
+	msgId, sendTime, _ := strings.Cut(exist, ":")
+	first := *res
+	first.MsgId = msgId
+	first.SendTime, _ = strconv.ParseInt(sendTime, 10, 64)
+	first.Duplicate = true
+	return &first, nil
+}
+
+// releaseChat 删除去重记录，消息未能投递到消息队列时调用，允许客户端使用相同的ID重发。
+func releaseChat(svc *svc.ServiceContext, sendId, clientMsgId string) error {
+	_, err := svc.Del(chatDedupKey(sendId, clientMsgId))
+	return err
+}
@@ -0,0 +1,171 @@
+package conversation
+
+import (
+	"context"
+	"im-chat/easy-chat/apps/im/ws/internal/svc"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/apps/social/rpc/socialclient"
+	"im-chat/easy-chat/pkg/constants"
+	"im-chat/easy-chat/pkg/wuid"
+	"im-chat/easy-chat/pkg/xerr"
+	"strings"
+	"time"
+)
+
+// TypingEventType 正在输入事件的类型。
+const TypingEventType = "typing"
+
+// Typing 处理 WebSocket 消息，向会话的其他成员转发正在输入的状态。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，消息体为 ws.Event，事件类型固定为 typing，
+// Content 可以为 start 或 stop。转发过程与 Event 相同。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Typing(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Event
+		if err := conn.Bind(msg, &data); err != nil {
+			srv.Send(websocket.NewErrReply(msg, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))), conn)
+			return
+		}
+		data.EventType = TypingEventType
+
+		relay(svc, srv, conn, msg, &data)
+	}
+}
+
+// Event 处理 WebSocket 消息，向会话的其他成员转发不持久化的事件。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，消息体为 ws.Event。事件不会写入消息队列及聊天记录，
+// 只经由推送路径转发给在线的会话成员：当前节点上的成员直接发送，其他节点上的成员通过 Dispatcher 转发。
+// 同一用户在同一会话中重复的事件在节流间隔内只转发一次，事件在有效期之后自动失效。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Event(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Event
+		if err := conn.Bind(msg, &data); err != nil || data.EventType == "" {
+			srv.Send(websocket.NewErrReply(msg, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))), conn)
+			return
+		}
+
+		relay(svc, srv, conn, msg, &data)
+	}
+}
+
+// relay 节流后将事件转发给会话的其他成员。
+func relay(svc *svc.ServiceContext, srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message, data *ws.Event) {
+	data.SendId = conn.Uid
+	data.RecvIds = nil
+	data.ExpireAt = time.Now().Add(svc.Config.ConversationEvent.TTL).UnixMilli()
+	if data.ConversationId == "" {
+		switch data.ChatType {
+		case constants.SingleChatType:
+			data.ConversationId = wuid.CombineId(conn.Uid, data.RecvId)
+		case constants.GroupChatType:
+			data.ConversationId = data.RecvId
+		}
+	}
+
+	if throttled(svc, data) {
+		return
+	}
+
+	recvIds, err := eventRecvIds(svc, data)
+	if err != nil {
+		srv.Errorf("event recv ids uid %v conversation %v err %v", conn.Uid, data.ConversationId, err)
+		srv.Send(websocket.NewErrReply(msg, err), conn)
+		return
+	}
+
+	if err := deliverEvent(svc, srv, data, recvIds); err != nil {
+		srv.Errorf("deliver event uid %v conversation %v err %v", conn.Uid, data.ConversationId, err)
+	}
+}
+
+// throttled 判断事件是否在节流间隔内已经转发过，内容不同的事件（例如 start 与 stop）分别节流。
+func throttled(svc *svc.ServiceContext, data *ws.Event) bool {
+	key := strings.Join([]string{data.SendId, data.ConversationId, data.EventType, data.Content}, ":")
+
+	fresh := false
+	svc.EventThrottle.Take(key, func() (any, error) {
+		fresh = true
+		return struct{}{}, nil
+	})
+	return !fresh
+}
+
+// eventRecvIds 获取需要接收事件的会话成员，群聊时发送者必须是群成员。
+func eventRecvIds(svc *svc.ServiceContext, data *ws.Event) ([]string, error) {
+	switch data.ChatType {
+	case constants.SingleChatType:
+		if data.RecvId == "" {
+			return nil, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))
+		}
+		return []string{data.RecvId}, nil
+	case constants.GroupChatType:
+		users, err := svc.Social.GroupUsers(context.Background(), &socialclient.GroupUsersReq{
+			GroupId: data.RecvId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		member := false
+		recvIds := make([]string, 0, len(users.List))
+		for _, u := range users.List {
+			if u.UserId == data.SendId {
+				member = true
+				continue
+			}
+			recvIds = append(recvIds, u.UserId)
+		}
+		if !member {
+			return nil, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))
+		}
+		return recvIds, nil
+	default:
+		return nil, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))
+	}
+}
+
+// deliverEvent 将事件投递到接收者所在的节点，当前节点上的接收者直接发送。
+func deliverEvent(svc *svc.ServiceContext, srv *websocket.Server, data *ws.Event, recvIds []string) error {
+	return svc.Dispatcher.Dispatch(recvIds, func(node string, nodeUids []string) any {
+		if node == svc.Node() {
+			srv.Send(NewEventMessage(data), srv.GetConns(nodeUids...)...)
+			return nil
+		}
+
+		event := *data
+		event.RecvIds = nodeUids
+		return websocket.Message{
+			FrameType: websocket.FrameData,
+			Method:    "push.event",
+			FormId:    constants.SYSTEM_ROOT_UID,
+			Data:      &event,
+		}
+	})
+}
+
+// NewEventMessage 将事件转换为下发给客户端的消息，正在输入的事件使用 conversation.typing，其余使用 conversation.event。
+func NewEventMessage(data *ws.Event) *websocket.Message {
+	event := *data
+	event.RecvIds = nil
+
+	msg := websocket.NewMessage(data.SendId, &event)
+	msg.Method = "conversation.event"
+	if data.EventType == TypingEventType {
+		msg.Method = "conversation.typing"
+	}
+	return msg
+}
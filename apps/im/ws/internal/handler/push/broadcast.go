@@ -0,0 +1,107 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"im-chat/easy-chat/apps/im/ws/internal/svc"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/apps/social/rpc/socialclient"
+	"im-chat/easy-chat/pkg/constants"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// Broadcast 处理 WebSocket 消息，向当前节点上投递范围内的连接广播 im.rpc 投递的系统公告。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，只接受系统用户（Dispatcher）的投递。
+// 公告由 Server.Broadcast 分批并发发送，完成后在 Redis 中累计当前节点送达及失败的连接数；
+// 公告已由 im.rpc 保存，离线的用户上线后自行拉取，这里不保存离线消息。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Broadcast(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		if conn.Uid != constants.SYSTEM_ROOT_UID {
+			return
+		}
+
+		var data ws.Broadcast
+		if err := conn.Bind(msg, &data); err != nil {
+			srv.Errorf("bind push broadcast err %v", err)
+			return
+		}
+		if data.ExpireAt > 0 && data.ExpireAt < time.Now().UnixMilli() {
+			return
+		}
+
+		// 广播的连接数较多，不阻塞系统用户连接上的其他推送
+		threading.GoSafe(func() {
+			filter, err := broadcastFilter(svc, &data)
+			if err != nil {
+				srv.Errorf("broadcast %v filter err %v", data.Id, err)
+				return
+			}
+
+			res := srv.Broadcast(NewBroadcastMessage(&data), filter)
+			srv.Infof("broadcast %v delivered %v failed %v", data.Id, res.Delivered, res.Failed)
+
+			if err := recordBroadcast(svc, data.Id, res); err != nil {
+				srv.Errorf("record broadcast %v err %v", data.Id, err)
+			}
+		})
+	}
+}
+
+// NewBroadcastMessage 将系统公告转换为下发给客户端的消息，方法为 system.announcement。
+func NewBroadcastMessage(data *ws.Broadcast) *websocket.Message {
+	msg := websocket.NewMessage(constants.SYSTEM_ROOT_UID, data)
+	msg.Method = "system.announcement"
+	return msg
+}
+
+// broadcastFilter 根据公告的投递范围筛选连接，系统用户的连接不接收公告。
+func broadcastFilter(svc *svc.ServiceContext, data *ws.Broadcast) (func(conn *websocket.Conn) bool, error) {
+	switch data.BroadcastSegment {
+	case constants.BroadcastGroup:
+		users, err := svc.Social.GroupUsers(context.Background(), &socialclient.GroupUsersReq{
+			GroupId: data.GroupId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		members := make(map[string]struct{}, len(users.List))
+		for _, u := range users.List {
+			members[u.UserId] = struct{}{}
+		}
+		return func(conn *websocket.Conn) bool {
+			_, ok := members[conn.Uid]
+			return ok
+		}, nil
+	case constants.BroadcastVersion:
+		return func(conn *websocket.Conn) bool {
+			return conn.Uid != constants.SYSTEM_ROOT_UID && conn.Version == data.Version
+		}, nil
+	default:
+		return func(conn *websocket.Conn) bool {
+			return conn.Uid != constants.SYSTEM_ROOT_UID
+		}, nil
+	}
+}
+
+// recordBroadcast 累计公告的投递统计，im.rpc 通过 GetBroadcastStats 查询。
+func recordBroadcast(svc *svc.ServiceContext, id string, res websocket.BroadcastResult) error {
+	key := fmt.Sprintf("%s:%s", constants.REDIS_BROADCAST_STATS, id)
+	return svc.PipelinedCtx(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(context.Background(), key, "nodes", 1)
+		pipe.HIncrBy(context.Background(), key, "delivered", int64(res.Delivered))
+		pipe.HIncrBy(context.Background(), key, "failed", int64(res.Failed))
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package push
+
+import (
+	"im-chat/easy-chat/apps/im/ws/internal/handler/user"
+	"im-chat/easy-chat/apps/im/ws/internal/svc"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/pkg/constants"
+)
+
+// Presence 处理 WebSocket 消息，转发其他 im.ws 节点投递的在线状态。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，只接受系统用户（Dispatcher）的投递，
+// 在线状态直接发送给当前节点上的接收者，不需要确认也不保存离线消息。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Presence(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		if conn.Uid != constants.SYSTEM_ROOT_UID {
+			return
+		}
+
+		var data ws.Presence
+		if err := conn.Bind(msg, &data); err != nil {
+			srv.Errorf("bind push presence err %v", err)
+			return
+		}
+
+		srv.Send(user.NewPresenceMessage(&data), srv.GetConns(data.RecvIds...)...)
+	}
+}
@@ -1,17 +1,18 @@
 package push
 
 import (
-	"github.com/mitchellh/mapstructure"
 	"im-chat/easy-chat/apps/im/ws/internal/svc"
 	"im-chat/easy-chat/apps/im/ws/websocket"
 	"im-chat/easy-chat/apps/im/ws/ws"
 	"im-chat/easy-chat/pkg/constants"
+	"im-chat/easy-chat/pkg/xerr"
 )
 
 // Push 处理 WebSocket 消息，转发推送消息，由 kafka 消息队列远程调用。
 //
 // 该函数返回一个 websocket.HandlerFunc 处理函数，用于接收并处理推送消息。
 // 它将 WebSocket 消息解码为 ws.Push 结构体，并根据聊天类型将消息推送到目标用户。
+// 私聊时 RecvIds 为当前节点上的接收者，接收者不在当前节点上时为空，只同步到发送者的其他设备。
 // 如果消息解码失败，或推送过程中出现错误，将通过 WebSocket 向客户端发送错误信息。
 //
 // 参数:
@@ -22,24 +23,29 @@ import (
 func Push(svc *svc.ServiceContext) websocket.HandlerFunc {
 	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
 		var data ws.Push
-		if err := mapstructure.Decode(msg.Data, &data); err != nil {
-			srv.Send(websocket.NewErrMessage(err))
+		if err := conn.Bind(msg, &data); err != nil {
+			srv.Send(websocket.NewErrReply(msg, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))), conn)
 			return
 		}
 
 		switch data.ChatType {
 		case constants.SingleChatType:
-			single(srv, &data, data.RecvId)
+			for _, id := range data.RecvIds {
+				single(srv, &data, id)
+			}
 		case constants.GroupChatType:
 			group(srv, &data)
 		}
+
+		echo(srv, &data)
 	}
 }
 
 // single 处理单聊消息的推送。
 //
-// 该函数根据接收者ID从服务器获取连接，并将消息推送给接收者。
-// 如果目标用户离线，当前实现没有处理离线用户的逻辑。
+// 该函数根据接收者ID从服务器获取该用户所有设备的连接并推送消息，开启推送确认的连接
+// 未确认的消息会在重发后保存到离线消息中。
+// 如果目标用户在当前节点上没有任何连接（例如推送期间断开），消息保存为离线消息，该用户任意设备连接后推送，已读回执不保存。
 // 如果推送过程中出现错误，记录错误日志。
 //
 // 参数:
@@ -51,34 +57,63 @@ func Push(svc *svc.ServiceContext) websocket.HandlerFunc {
 //   - error: 发生的错误（如果有的话），返回nil表示推送成功。
 func single(srv *websocket.Server, data *ws.Push, recvId string) error {
 	// 发送的目标
-	rconn := srv.GetConn(recvId)
-	if rconn == nil {
-		// todo: 目标离线
+	rconns := srv.GetConns(recvId)
+	if len(rconns) == 0 {
+		// 目标离线，保存为离线消息，任意设备连接后推送
+		if data.ContentType != constants.ContentMarkRead {
+			srv.SaveOffline(recvId, newChatMessage(data))
+		}
 		return nil
 	}
 
 	srv.Infof("push msg %v", data)
 
-	return srv.Send(websocket.NewMessage(data.SendId, &ws.Chat{
-		ConversationId: data.ConversationId,
-		ChatType:       data.ChatType,
-		SendTime:       data.SendTime,
-		Msg: ws.Msg{
-			ReadRecords: data.ReadRecords,
-			MsgId:       data.MsgId,
-			MType:       data.MType,
-			Content:     data.Content,
-		},
-	}), rconn)
+	return srv.SendWithAck(newChatMessage(data), rconns...)
 }
 
 func group(srv *websocket.Server, data *ws.Push) error {
 	for _, id := range data.RecvIds {
 		func(id string) {
 			srv.Schedule(func() {
-				single(srv, data, data.RecvId)
+				single(srv, data, id)
 			})
 		}(id)
 	}
 	return nil
 }
+
+// echo 将发送者发出的消息同步到发送者的其他设备上。
+//
+// 发送消息的设备本身不会收到同步，未携带发送设备的推送（例如已读回执）不做同步。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - data: 包含推送消息的数据结构体。
+//
+// 返回:
+//   - error: 发生的错误（如果有的话），返回nil表示推送成功。
+func echo(srv *websocket.Server, data *ws.Push) error {
+	if data.SendDeviceId == "" {
+		return nil
+	}
+
+	conns := srv.GetConns(data.SendId)
+	others := make([]*websocket.Conn, 0, len(conns))
+	for _, c := range conns {
+		if c.DeviceId != data.SendDeviceId {
+			others = append(others, c)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	return srv.SendWithAck(newChatMessage(data), others...)
+}
+
+// newChatMessage 将推送数据转换为下发给客户端的聊天消息，在线推送及离线消息都使用 push 路由。
+func newChatMessage(data *ws.Push) *websocket.Message {
+	msg := websocket.NewMessage(data.SendId, data.Chat())
+	msg.Method = "push"
+	return msg
+}
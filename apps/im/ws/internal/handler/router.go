@@ -9,11 +9,20 @@ import (
 )
 
 func RegisterHandlers(srv *websocket.Server, svc *svc.ServiceContext) {
+	// 用户上下线后通知好友及群成员
+	notifier := user.NewPresenceNotifier(svc)
+	srv.OnConnect(notifier.Touch)
+	srv.OnClose(notifier.Touch)
+
 	srv.AddRoutes([]websocket.Route{
 		{
 			Method:  "user.online",
 			Handler: user.OnLine(svc),
 		},
+		{
+			Method:  "user.status",
+			Handler: user.Status(svc, notifier),
+		},
 		{
 			Method:  "conversation.chat",
 			Handler: conversation.Chat(svc),
@@ -22,9 +31,29 @@ func RegisterHandlers(srv *websocket.Server, svc *svc.ServiceContext) {
 			Method:  "conversation.markChat",
 			Handler: conversation.MarkRead(svc),
 		},
+		{
+			Method:  "conversation.typing",
+			Handler: conversation.Typing(svc),
+		},
+		{
+			Method:  "conversation.event",
+			Handler: conversation.Event(svc),
+		},
 		{
 			Method:  "push",
 			Handler: push.Push(svc),
 		},
+		{
+			Method:  "push.event",
+			Handler: push.Event(svc),
+		},
+		{
+			Method:  "push.presence",
+			Handler: push.Presence(svc),
+		},
+		{
+			Method:  "push.broadcast",
+			Handler: push.Broadcast(svc),
+		},
 	})
 }
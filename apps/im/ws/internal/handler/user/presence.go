@@ -0,0 +1,207 @@
+package user
+
+import (
+	"context"
+	"im-chat/easy-chat/apps/im/ws/internal/svc"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/apps/social/rpc/socialclient"
+	"im-chat/easy-chat/pkg/constants"
+	"im-chat/easy-chat/pkg/presence"
+	"im-chat/easy-chat/pkg/xerr"
+	"sync"
+	"time"
+)
+
+// PresenceNotifier 在用户上下线或修改在线状态后，将在线状态推送给该用户在线的好友及群成员。
+//
+// 同一用户的变化在 Debounce 内合并为一次通知，通知时重新查询用户在线状态，并与最近一次通知的状态比较，
+// 连接断开后很快重新连接（例如网络切换）时状态没有变化，不会通知。
+type PresenceNotifier struct {
+	svc *svc.ServiceContext
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// NewPresenceNotifier 创建在线状态的通知。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext。
+//
+// 返回:
+//   - *PresenceNotifier: 在线状态的通知，通过 Server.OnConnect 及 Server.OnClose 注册 Touch。
+func NewPresenceNotifier(svc *svc.ServiceContext) *PresenceNotifier {
+	return &PresenceNotifier{
+		svc:    svc,
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+// Touch 记录连接的用户在线状态可能发生变化，在 Debounce 之后通知，系统用户的连接不通知。
+func (n *PresenceNotifier) Touch(srv *websocket.Server, conn *websocket.Conn) {
+	if conn.Uid == constants.SYSTEM_ROOT_UID {
+		return
+	}
+	n.schedule(srv, conn.Uid)
+}
+
+// schedule 重新计时用户的通知，之前未执行的通知被取消。
+func (n *PresenceNotifier) schedule(srv *websocket.Server, uid string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if t := n.timers[uid]; t != nil {
+		t.Stop()
+	}
+
+	var t *time.Timer
+	t = time.AfterFunc(n.svc.Config.PresenceEvent.Debounce, func() {
+		n.mu.Lock()
+		if n.timers[uid] == t {
+			delete(n.timers, uid)
+		}
+		n.mu.Unlock()
+
+		n.notify(srv, uid)
+	})
+	n.timers[uid] = t
+}
+
+// notify 查询用户当前的在线状态，与最近一次通知的状态不同时推送给在线的好友及群成员。
+func (n *PresenceNotifier) notify(srv *websocket.Server, uid string) {
+	ctx := context.Background()
+
+	status, err := n.svc.Presence.Lookup(ctx, uid)
+	if err != nil {
+		srv.Errorf("presence lookup uid %v err %v", uid, err)
+		return
+	}
+	s := status[uid]
+
+	changed, err := n.svc.Presence.Notified(ctx, uid, s.State)
+	if err != nil {
+		srv.Errorf("presence notified uid %v err %v", uid, err)
+		return
+	}
+	if !changed {
+		return
+	}
+
+	recvIds, err := n.recvIds(ctx, uid)
+	if err != nil {
+		srv.Errorf("presence recv ids uid %v err %v", uid, err)
+		return
+	}
+
+	data := &ws.Presence{
+		Uid:      uid,
+		State:    s.State,
+		LastSeen: s.LastSeen,
+	}
+	if err := deliverPresence(n.svc, srv, data, recvIds); err != nil {
+		srv.Errorf("deliver presence uid %v err %v", uid, err)
+	}
+}
+
+// recvIds 获取需要接收通知的用户：所有好友，以及成员数不超过 MaxGroupSize 的群的成员。
+func (n *PresenceNotifier) recvIds(ctx context.Context, uid string) ([]string, error) {
+	friends, err := n.svc.Social.FriendList(ctx, &socialclient.FriendListReq{
+		UserId: uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]struct{}, len(friends.List))
+	for _, friend := range friends.List {
+		set[friend.FriendUid] = struct{}{}
+	}
+
+	groups, err := n.svc.Social.GroupList(ctx, &socialclient.GroupListReq{
+		UserId: uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups.List {
+		users, err := n.svc.Social.GroupUsers(ctx, &socialclient.GroupUsersReq{
+			GroupId: group.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(users.List) > n.svc.Config.PresenceEvent.MaxGroupSize {
+			continue
+		}
+		for _, u := range users.List {
+			set[u.UserId] = struct{}{}
+		}
+	}
+	delete(set, uid)
+
+	recvIds := make([]string, 0, len(set))
+	for id := range set {
+		recvIds = append(recvIds, id)
+	}
+	return recvIds, nil
+}
+
+// deliverPresence 将在线状态投递到接收者所在的节点，当前节点上的接收者直接发送，离线的接收者不会投递。
+func deliverPresence(svc *svc.ServiceContext, srv *websocket.Server, data *ws.Presence, recvIds []string) error {
+	return svc.Dispatcher.Dispatch(recvIds, func(node string, nodeUids []string) any {
+		if node == svc.Node() {
+			srv.Send(NewPresenceMessage(data), srv.GetConns(nodeUids...)...)
+			return nil
+		}
+
+		p := *data
+		p.RecvIds = nodeUids
+		return websocket.Message{
+			FrameType: websocket.FrameData,
+			Method:    "push.presence",
+			FormId:    constants.SYSTEM_ROOT_UID,
+			Data:      &p,
+		}
+	})
+}
+
+// NewPresenceMessage 将在线状态转换为下发给客户端的消息，方法为 user.presence。
+func NewPresenceMessage(data *ws.Presence) *websocket.Message {
+	p := *data
+	p.RecvIds = nil
+
+	msg := websocket.NewMessage(data.Uid, &p)
+	msg.Method = "user.presence"
+	return msg
+}
+
+// Status 处理 WebSocket 消息，设置用户的在线状态。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，消息体为 ws.PresenceState，状态可以为 online、away、busy 或 invisible。
+// 设置成功后回复结果，并通知用户的好友及群成员；隐身的用户对其他用户显示为离线。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext。
+//   - notifier: 在线状态的通知。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Status(svc *svc.ServiceContext, notifier *PresenceNotifier) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.PresenceState
+		if err := conn.Bind(msg, &data); err != nil || !presence.ValidState(data.State) {
+			srv.Send(websocket.NewErrReply(msg, xerr.New(xerr.REQUEST_PARAM_ERROR, xerr.ErrMsg(xerr.REQUEST_PARAM_ERROR))), conn)
+			return
+		}
+
+		if err := svc.Presence.SetState(context.Background(), conn.Uid, data.State); err != nil {
+			srv.Errorf("presence set state uid %v err %v", conn.Uid, err)
+			srv.Send(websocket.NewErrReply(msg, xerr.NewDBErr()), conn)
+			return
+		}
+
+		srv.Send(websocket.NewResultMessage(msg, nil), conn)
+		notifier.Touch(srv, conn)
+	}
+}
@@ -1,24 +1,81 @@
 package svc
 
 import (
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
 	"im-chat/easy-chat/apps/im/immodels"
 	"im-chat/easy-chat/apps/im/ws/internal/config"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/apps/social/rpc/socialclient"
 	"im-chat/easy-chat/apps/task/mq/mqclient"
+	"im-chat/easy-chat/pkg/constants"
+	"im-chat/easy-chat/pkg/presence"
+	"net/http"
 )
 
 type ServiceContext struct {
 	Config config.Config
 
+	*redis.Redis
+
 	immodels.ChatLogModel
 	mqclient.MsgChatTransferClient
 	mqclient.MsgReadTransferClient
+
+	socialclient.Social
+
+	// 用户的在线状态
+	Presence *presence.Presence
+
+	// 将会话事件转发到其他 im.ws 节点
+	Locator    websocket.Locator
+	Dispatcher *websocket.Dispatcher
+	// 会话事件的节流记录
+	EventThrottle *collection.Cache
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
-	return &ServiceContext{
+	svc := &ServiceContext{
 		Config:                c,
+		Redis:                 redis.MustNewRedis(c.Redisx),
 		MsgChatTransferClient: mqclient.NewMsgChatTransferClient(c.MsgChatTransfer.Addrs, c.MsgChatTransfer.Topic),
 		MsgReadTransferClient: mqclient.NewmsgReadTransferClient(c.MsgReadTransfer.Addrs, c.MsgReadTransfer.Topic),
 		ChatLogModel:          immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
+		Social:                socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
+	}
+	svc.Locator = websocket.NewRedisLocator(svc.Redis, 0)
+	svc.Presence = presence.NewPresence(svc.Redis, 0)
+
+	eventThrottle, err := collection.NewCache(c.ConversationEvent.Throttle)
+	if err != nil {
+		panic(err)
+	}
+	svc.EventThrottle = eventThrottle
+
+	token, err := svc.GetSystemToken()
+	if err != nil {
+		panic(err)
 	}
+	header := http.Header{}
+	header.Set("Authorization", token)
+	opts := []websocket.DailOptions{websocket.WithClientHeader(header)}
+	if c.CertFile != "" {
+		opts = append(opts, websocket.WithClientTLS(nil))
+	}
+	svc.Dispatcher = websocket.NewDispatcher(svc.Locator, opts...)
+
+	return svc
+}
+
+// Node 当前节点对外的访问地址，与 Locator 中记录的节点一致。
+func (svc *ServiceContext) Node() string {
+	if svc.Config.AdvertiseAddr != "" {
+		return svc.Config.AdvertiseAddr
+	}
+	return svc.Config.ListenOn
+}
+
+func (svc *ServiceContext) GetSystemToken() (string, error) {
+	return svc.Redis.Get(constants.REDIS_SYSTEM_ROOT_TOKEN)
 }
@@ -0,0 +1,389 @@
+package websocket
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ackItem 调度器中的一个定时任务。
+type ackItem struct {
+	at    time.Time
+	fn    func()
+	index int
+}
+
+type ackHeap []*ackItem
+
+func (h ackHeap) Len() int           { return len(h) }
+func (h ackHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h ackHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *ackHeap) Push(x any) {
+	item := x.(*ackItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *ackHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
+
+// ackScheduler 所有连接共享的确认超时调度器。
+//
+// 调度器使用最小堆维护所有等待确认的消息的超时时间，由一个协程在最早的超时时间到达时执行任务，
+// 没有等待确认的消息时不会占用 CPU。任务在调度器的协程中执行，不能阻塞。
+type ackScheduler struct {
+	mu    sync.Mutex
+	items ackHeap
+
+	wake chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func newAckScheduler() *ackScheduler {
+	s := &ackScheduler{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// schedule 在 d 之后执行 fn。
+func (s *ackScheduler) schedule(d time.Duration, fn func()) *ackItem {
+	item := &ackItem{at: time.Now().Add(d), fn: fn}
+
+	s.mu.Lock()
+	heap.Push(&s.items, item)
+	first := item.index == 0
+	s.mu.Unlock()
+
+	// 新任务最早到期时唤醒调度协程重新计算等待时间
+	if first {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return item
+}
+
+// cancel 取消尚未执行的任务。
+func (s *ackScheduler) cancel(item *ackItem) {
+	if item == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item.index >= 0 && item.index < len(s.items) && s.items[item.index] == item {
+		heap.Remove(&s.items, item.index)
+	}
+}
+
+func (s *ackScheduler) stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *ackScheduler) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		var due []func()
+		for len(s.items) > 0 && !s.items[0].at.After(now) {
+			due = append(due, heap.Pop(&s.items).(*ackItem).fn)
+		}
+		wait := time.Hour
+		if len(s.items) > 0 {
+			wait = s.items[0].at.Sub(now)
+		}
+		s.mu.Unlock()
+
+		for _, fn := range due {
+			fn()
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-s.wake:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// AckStats 消息确认的统计信息，包含客户端消息的确认（RigorAck）及服务端推送的确认。
+type AckStats struct {
+	// Acked 已确认的消息数
+	Acked int64
+	// Expired 超时未确认的消息数
+	Expired int64
+	// Retries 重发的次数
+	Retries int64
+	// AvgLatency 平均确认耗时
+	AvgLatency time.Duration
+	// MaxLatency 最大确认耗时
+	MaxLatency time.Duration
+}
+
+type ackStats struct {
+	acked      atomic.Int64
+	expired    atomic.Int64
+	retries    atomic.Int64
+	latency    atomic.Int64
+	maxLatency atomic.Int64
+}
+
+func (a *ackStats) observe(latency time.Duration) {
+	a.acked.Add(1)
+	a.latency.Add(int64(latency))
+	for {
+		max := a.maxLatency.Load()
+		if int64(latency) <= max || a.maxLatency.CompareAndSwap(max, int64(latency)) {
+			return
+		}
+	}
+}
+
+// AckStats 返回服务器消息确认的统计信息。
+func (s *Server) AckStats() AckStats {
+	stats := AckStats{
+		Acked:      s.ackStats.acked.Load(),
+		Expired:    s.ackStats.expired.Load(),
+		Retries:    s.ackStats.retries.Load(),
+		MaxLatency: time.Duration(s.ackStats.maxLatency.Load()),
+	}
+	if stats.Acked > 0 {
+		stats.AvgLatency = time.Duration(s.ackStats.latency.Load() / stats.Acked)
+	}
+	return stats
+}
+
+// ackEntry 需要确认的客户端消息。
+//
+// 字段:
+//   - msg: 客户端发送的消息。
+//   - item: RigorAck 模式下重发确认的定时任务。
+//   - confirmed: 是否已经确认，确认后等待处理完成，期间重复的消息会被忽略。
+type ackEntry struct {
+	msg       *Message
+	item      *ackItem
+	confirmed bool
+}
+
+// ackAction 释放锁之后需要执行的操作。
+type ackAction struct {
+	acks     []*Message
+	dispatch []*Message
+}
+
+func (a *ackAction) do(s *Server, conn *Conn) {
+	for _, ack := range a.acks {
+		s.Send(ack, conn)
+	}
+	for _, msg := range a.dispatch {
+		conn.dispatch(msg)
+	}
+}
+
+// receiveAck 处理需要确认的客户端消息。
+//
+// OnlyAck 模式下直接回复确认并处理消息；RigorAck 模式下回复确认后等待客户端的再次确认，
+// 等待确认的消息数超过 WithServerAckWindow 设置的窗口时，新消息排队等待。
+//
+// 参数:
+//   - conn: 接收到消息的连接。
+//   - msg: 接收到的消息。
+func (s *Server) receiveAck(conn *Conn, msg *Message) {
+	var action ackAction
+
+	conn.messageMu.Lock()
+	if msg.FrameType == FrameAck {
+		// 客户端对服务端确认的再次确认
+		if e, ok := conn.acks[msg.Id]; ok && !e.confirmed && msg.AckSeq > e.msg.AckSeq {
+			s.confirmAck(conn, e, &action)
+			s.admitBacklog(conn, &action)
+		}
+		conn.messageMu.Unlock()
+		action.do(s, conn)
+		return
+	}
+
+	if conn.isDuplicateAck(msg.Id) {
+		conn.messageMu.Unlock()
+		return
+	}
+
+	switch {
+	case s.opt.ack == OnlyAck || s.draining.Load():
+		// 服务停止时不再等待客户端的确认，直接处理
+		conn.acks[msg.Id] = &ackEntry{msg: msg, confirmed: true}
+		action.acks = append(action.acks, &Message{FrameType: FrameAck, Id: msg.Id, AckSeq: msg.AckSeq + 1})
+		action.dispatch = append(action.dispatch, msg)
+	case conn.ackOutstanding >= s.opt.ackWindow:
+		conn.ackBacklog = append(conn.ackBacklog, msg)
+	default:
+		s.admitAck(conn, msg, &action)
+	}
+	conn.messageMu.Unlock()
+
+	action.do(s, conn)
+}
+
+// isDuplicateAck 判断消息是否正在等待确认或处理，调用方需要持有 messageMu。
+func (c *Conn) isDuplicateAck(id string) bool {
+	if _, ok := c.acks[id]; ok {
+		return true
+	}
+	for _, m := range c.ackBacklog {
+		if m.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// admitAck 回复确认并开始等待客户端的再次确认，调用方需要持有 messageMu。
+func (s *Server) admitAck(conn *Conn, msg *Message, action *ackAction) {
+	msg.AckSeq++
+	msg.ackTime = time.Now()
+
+	e := &ackEntry{msg: msg}
+	conn.acks[msg.Id] = e
+	conn.ackOutstanding++
+
+	e.item = s.scheduler.schedule(s.opt.ackRetryInterval, func() {
+		s.retryAck(conn, msg.Id)
+	})
+	action.acks = append(action.acks, &Message{FrameType: FrameAck, Id: msg.Id, AckSeq: msg.AckSeq})
+	s.Infof("message ack RigorAck send mid %v, seq %v , time%v", msg.Id, msg.AckSeq, msg.ackTime)
+}
+
+// admitBacklog 确认窗口有空余时处理排队的消息，调用方需要持有 messageMu。
+func (s *Server) admitBacklog(conn *Conn, action *ackAction) {
+	for len(conn.ackBacklog) > 0 && conn.ackOutstanding < s.opt.ackWindow {
+		msg := conn.ackBacklog[0]
+		conn.ackBacklog = conn.ackBacklog[1:]
+		s.admitAck(conn, msg, action)
+	}
+}
+
+// confirmAck 客户端已经确认，交给处理函数处理，调用方需要持有 messageMu。
+func (s *Server) confirmAck(conn *Conn, e *ackEntry, action *ackAction) {
+	e.confirmed = true
+	conn.ackOutstanding--
+	s.scheduler.cancel(e.item)
+
+	latency := time.Since(e.msg.ackTime)
+	s.ackStats.observe(latency)
+	observeAck(ackKindRigor, latency)
+	action.dispatch = append(action.dispatch, e.msg)
+	s.Infof("message ack RigorAck success mid %v", e.msg.Id)
+}
+
+// retryAck 客户端未在重发间隔内确认时重发确认，超过 WithServerAckTimeout 设置的时间后放弃该消息。
+//
+// 在调度器的协程中执行，不会调用处理函数。
+func (s *Server) retryAck(conn *Conn, id string) {
+	var action ackAction
+
+	conn.messageMu.Lock()
+	e, ok := conn.acks[id]
+	if !ok || e.confirmed {
+		conn.messageMu.Unlock()
+		return
+	}
+
+	select {
+	case <-conn.done:
+		conn.messageMu.Unlock()
+		return
+	default:
+	}
+
+	remain := s.opt.ackTimeout - time.Since(e.msg.ackTime)
+	if remain <= 0 {
+		// 超过确认时间，放弃该消息
+		delete(conn.acks, id)
+		conn.ackOutstanding--
+		s.ackStats.expired.Add(1)
+		metricAckTimeouts.Inc(ackKindRigor)
+		s.admitBacklog(conn, &action)
+		conn.messageMu.Unlock()
+
+		s.Infof("message ack RigorAck timeout mid %v", id)
+		action.do(s, conn)
+		return
+	}
+
+	e.msg.errCount++
+	s.ackStats.retries.Add(1)
+	e.item = s.scheduler.schedule(min(s.opt.ackRetryInterval, remain), func() {
+		s.retryAck(conn, id)
+	})
+	action.acks = append(action.acks, &Message{FrameType: FrameAck, Id: id, AckSeq: e.msg.AckSeq})
+	conn.messageMu.Unlock()
+
+	action.do(s, conn)
+}
+
+// ackDone 消息处理完成，之后相同 Id 的消息不再视为重复。
+func (s *Server) ackDone(conn *Conn, id string) {
+	conn.messageMu.Lock()
+	defer conn.messageMu.Unlock()
+
+	if e, ok := conn.acks[id]; ok && e.confirmed {
+		delete(conn.acks, id)
+	}
+}
+
+// flushAcks 服务停止时不再等待客户端的确认，按照接收的顺序处理所有等待确认及排队的消息。
+func (s *Server) flushAcks(conn *Conn) {
+	var action ackAction
+
+	conn.messageMu.Lock()
+	pending := make([]*ackEntry, 0, conn.ackOutstanding)
+	for _, e := range conn.acks {
+		if !e.confirmed {
+			pending = append(pending, e)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].msg.ackTime.Before(pending[j].msg.ackTime)
+	})
+	for _, e := range pending {
+		e.confirmed = true
+		conn.ackOutstanding--
+		s.scheduler.cancel(e.item)
+		action.dispatch = append(action.dispatch, e.msg)
+	}
+	for _, msg := range conn.ackBacklog {
+		conn.acks[msg.Id] = &ackEntry{msg: msg, confirmed: true}
+		action.dispatch = append(action.dispatch, msg)
+	}
+	conn.ackBacklog = nil
+	conn.messageMu.Unlock()
+
+	action.do(s, conn)
+}
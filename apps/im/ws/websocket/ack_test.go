@@ -0,0 +1,79 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestServer_RigorAck(t *testing.T) {
+	srv, hs := newTestServer(t,
+		WithServerAck(RigorAck),
+		WithServerAckRetryInterval(30*time.Millisecond),
+		WithServerAckTimeout(200*time.Millisecond),
+	)
+	srv.AddRoutes([]Route{{Method: "echo", Handler: func(srv *Server, conn *Conn, msg *Message) {
+		srv.Send(NewMessage(conn.Uid, msg.Data), conn)
+	}}})
+
+	c := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	read := func() Message {
+		t.Helper()
+		var msg Message
+		c.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := c.ReadJSON(&msg); err != nil {
+			t.Fatalf("read err %v", err)
+		}
+		return msg
+	}
+	write := func(msg *Message) {
+		t.Helper()
+		if err := c.WriteJSON(msg); err != nil {
+			t.Fatalf("write err %v", err)
+		}
+	}
+
+	write(&Message{FrameType: FrameData, Id: "1", Method: "echo", Data: "hello"})
+
+	// 未确认时按照重发间隔重发确认
+	for i := 0; i < 2; i++ {
+		if msg := read(); msg.FrameType != FrameAck || msg.Id != "1" || msg.AckSeq != 1 {
+			t.Fatalf("ack = %+v, want id 1 seq 1", msg)
+		}
+	}
+
+	write(&Message{FrameType: FrameAck, Id: "1", AckSeq: 2})
+	for {
+		msg := read()
+		if msg.FrameType == FrameData {
+			if msg.Data != "hello" {
+				t.Fatalf("echo = %+v, want hello", msg)
+			}
+			break
+		}
+	}
+
+	// 超时未确认的消息不会被处理
+	write(&Message{FrameType: FrameData, Id: "2", Method: "echo", Data: "expired"})
+	waitFor(t, func() bool { return srv.AckStats().Expired == 1 })
+
+	c.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	for {
+		var msg Message
+		if err := c.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err) {
+				t.Fatalf("read err %v", err)
+			}
+			break
+		}
+		if msg.FrameType == FrameData {
+			t.Fatalf("expired message handled %+v", msg)
+		}
+	}
+
+	stats := srv.AckStats()
+	if stats.Acked != 1 || stats.Retries == 0 || stats.MaxLatency <= 0 {
+		t.Errorf("AckStats() = %+v", stats)
+	}
+}
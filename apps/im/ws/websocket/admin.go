@@ -0,0 +1,153 @@
+package websocket
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 管理接口的路由，相对 WithServerAdmin 设置的前缀，请求需要携带 Authorization: Bearer {token}：
+//   - GET /conns?uid=&limit=: 查看当前节点上的连接，可以按用户过滤，按连接时间排序。
+//   - POST /kick: 断开用户或用户某个设备的连接，请求体为 AdminKick。
+//   - GET /stats: 查看各个路由的调用统计及消息确认的统计。
+const (
+	adminConnsPath = "/conns"
+	adminKickPath  = "/kick"
+	adminStatsPath = "/stats"
+
+	defaultAdminLimit = 1000
+)
+
+// CloseKicked 管理员断开连接时使用的状态码。
+const CloseKicked = 4002
+
+// AdminKick 断开连接的请求。
+//
+// 字段:
+//   - Uid: 需要断开的用户。
+//   - DeviceId: 需要断开的设备，为空时断开用户所有设备的连接。
+//   - Reason: 断开的原因，作为关闭消息的原因返回给客户端。
+type AdminKick struct {
+	Uid      string `json:"uid"`
+	DeviceId string `json:"deviceId"`
+	Reason   string `json:"reason"`
+}
+
+// AdminConns 连接列表的响应。
+type AdminConns struct {
+	Users int        `json:"users"`
+	Total int        `json:"total"`
+	Conns []ConnInfo `json:"conns"`
+}
+
+// AdminStats 统计信息的响应。
+type AdminStats struct {
+	Users  int                   `json:"users"`
+	Conns  int                   `json:"conns"`
+	Routes map[string]RouteStats `json:"routes"`
+	Acks   AckStats              `json:"acks"`
+}
+
+// adminHandler 返回管理接口的路由处理，路由为去掉前缀后的路径。
+func (s *Server) adminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.adminAuth(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.URL.Path == adminConnsPath && r.Method == http.MethodGet:
+			s.adminConns(w, r)
+		case r.URL.Path == adminKickPath && r.Method == http.MethodPost:
+			s.adminKick(w, r)
+		case r.URL.Path == adminStatsPath && r.Method == http.MethodGet:
+			s.adminStats(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// adminAuth 校验请求携带的管理令牌。
+func (s *Server) adminAuth(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.opt.adminToken)) == 1
+}
+
+func (s *Server) adminConns(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultAdminLimit
+	}
+
+	var uids []string
+	if uid := query.Get("uid"); uid != "" {
+		uids = []string{uid}
+	} else {
+		uids = s.GetUsers()
+	}
+
+	conns := s.GetConns(uids...)
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].ConnectAt.Before(conns[j].ConnectAt)
+	})
+
+	res := AdminConns{
+		Users: len(uids),
+		Total: len(conns),
+		Conns: make([]ConnInfo, 0, min(len(conns), limit)),
+	}
+	for _, conn := range conns[:min(len(conns), limit)] {
+		res.Conns = append(res.Conns, conn.Info())
+	}
+	writeJSON(w, http.StatusOK, &res)
+}
+
+func (s *Server) adminKick(w http.ResponseWriter, r *http.Request) {
+	var req AdminKick
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Uid == "" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Reason == "" {
+		req.Reason = "kicked by admin"
+	}
+
+	var conns []*Conn
+	if req.DeviceId != "" {
+		if conn := s.GetDeviceConn(req.Uid, req.DeviceId); conn != nil {
+			conns = append(conns, conn)
+		}
+	} else {
+		conns = s.GetConns(req.Uid)
+	}
+
+	for _, conn := range conns {
+		s.Infof("admin kick uid %v device %v reason %v", conn.Uid, conn.DeviceId, req.Reason)
+		s.closeWithCode(conn, CloseKicked, req.Reason)
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"kicked": len(conns)})
+}
+
+func (s *Server) adminStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, &AdminStats{
+		Users:  len(s.GetUsers()),
+		Conns:  len(s.allConns()),
+		Routes: s.RouteStats(),
+		Acks:   s.AckStats(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
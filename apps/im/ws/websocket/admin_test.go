@@ -0,0 +1,76 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestServer_Admin(t *testing.T) {
+	srv := NewServer("", WithServerAuthentication(new(testAuthentication)), WithServerAdmin("/admin", "secret"))
+	srv.AddRoutes([]Route{{Method: "echo", Handler: func(srv *Server, conn *Conn, msg *Message) {}}})
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/admin/") {
+			srv.Handler().ServeHTTP(w, r)
+			return
+		}
+		srv.ServerWs(w, r)
+	}))
+	t.Cleanup(hs.Close)
+
+	admin := func(method, path, body string) *http.Response {
+		req, _ := http.NewRequest(method, hs.URL+"/admin"+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("admin %v %v err %v", method, path, err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	if resp, err := http.Get(hs.URL + "/admin/conns"); err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("conns without token = %v, %v, want 401", resp, err)
+	}
+
+	phone := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	dialTestServer(t, hs, "u1", "pc", DesktopDevice)
+	dialTestServer(t, hs, "u2", "phone", MobileDevice)
+	waitFor(t, func() bool { return len(srv.allConns()) == 3 })
+
+	var conns AdminConns
+	json.NewDecoder(admin(http.MethodGet, "/conns?uid=u1", "").Body).Decode(&conns)
+	if conns.Total != 2 || len(conns.Conns) != 2 || conns.Conns[0].DeviceId != "phone" || conns.Conns[0].RemoteAddr == "" {
+		t.Fatalf("conns = %+v, want u1 phone and pc", conns)
+	}
+
+	phone.WriteJSON(&Message{FrameType: FrameData, Method: "echo"})
+	phone.WriteJSON(&Message{FrameType: FrameData, Method: "missing"})
+	waitFor(t, func() bool {
+		routes := srv.RouteStats()
+		return routes["echo"].Calls == 1 && routes[unknownMethod].Errors == 1
+	})
+	var stats AdminStats
+	json.NewDecoder(admin(http.MethodGet, "/stats", "").Body).Decode(&stats)
+	if stats.Users != 2 || stats.Conns != 3 || stats.Routes["echo"].Calls != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	// 断开用户指定设备的连接
+	admin(http.MethodPost, "/kick", `{"uid":"u1","deviceId":"phone","reason":"maintenance"}`)
+	for {
+		_, _, err := phone.ReadMessage()
+		if err == nil {
+			continue
+		}
+		if !websocket.IsCloseError(err, CloseKicked) {
+			t.Fatalf("kicked conn err %v, want close %v", err, CloseKicked)
+		}
+		break
+	}
+	waitFor(t, func() bool { return len(srv.GetConns("u1")) == 1 })
+}
@@ -0,0 +1,92 @@
+package websocket
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BroadcastResult 广播的投递结果。
+//
+// 字段:
+//   - Delivered: 消息成功放入发送队列的连接数。
+//   - Failed: 发送失败的连接数，例如发送队列已满或连接已关闭。
+type BroadcastResult struct {
+	Delivered int `json:"delivered"`
+	Failed    int `json:"failed"`
+}
+
+// Broadcast 向当前节点上的连接广播消息，例如系统公告。
+//
+// 符合 filter 的连接按 WithServerBroadcastBatch 设置的大小分批，每批作为一个任务交给 TaskRunner 执行，
+// 同时执行的批次不超过 WithServerConcurrency 设置的并发数，所有批次完成后返回投递结果。
+//
+// 参数:
+//   - msg: 要广播的消息。
+//   - filter: 筛选接收消息的连接，为 nil 时向所有连接广播。
+//
+// 返回:
+//   - BroadcastResult: 投递成功及失败的连接数。
+func (s *Server) Broadcast(msg interface{}, filter func(conn *Conn) bool) BroadcastResult {
+	conns := s.allConns()
+	if filter != nil {
+		matched := conns[:0]
+		for _, conn := range conns {
+			if filter(conn) {
+				matched = append(matched, conn)
+			}
+		}
+		conns = matched
+	}
+
+	var (
+		wg                sync.WaitGroup
+		delivered, failed atomic.Int64
+	)
+	for start := 0; start < len(conns); start += s.opt.broadcastBatch {
+		batch := conns[start:min(start+s.opt.broadcastBatch, len(conns))]
+
+		wg.Add(1)
+		s.Schedule(func() {
+			defer wg.Done()
+
+			err := s.Send(msg, batch...)
+			var sendErrs SendErrors
+			switch {
+			case err == nil:
+				delivered.Add(int64(len(batch)))
+			case errors.As(err, &sendErrs):
+				delivered.Add(int64(len(batch) - len(sendErrs)))
+				failed.Add(int64(len(sendErrs)))
+			default:
+				s.Errorf("broadcast batch err %v", err)
+				failed.Add(int64(len(batch)))
+			}
+		})
+	}
+	wg.Wait()
+
+	return BroadcastResult{
+		Delivered: int(delivered.Load()),
+		Failed:    int(failed.Load()),
+	}
+}
+
+// alive 定期在 Locator 中刷新当前节点的存活记录，直到服务停止。
+func (s *Server) alive() {
+	ticker := time.NewTicker(s.opt.locatorRefresh)
+	defer ticker.Stop()
+
+	for {
+		if err := s.opt.locator.Alive(s.opt.node); err != nil {
+			s.Errorf("locator alive node %v err %v", s.opt.node, err)
+		}
+
+		select {
+		case <-s.stopped:
+			return
+		case <-ticker.C:
+		}
+	}
+}
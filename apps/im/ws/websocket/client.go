@@ -1,32 +1,103 @@
 package websocket
 
 import (
-	"encoding/json"
-	"github.com/gorilla/websocket"
+	"context"
+	"errors"
+	"math/rand"
 	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	// ErrClientClosed 客户端已经关闭，不再发送或接收消息。
+	ErrClientClosed = errors.New("websocket client closed")
+	// ErrClientQueueFull 断线期间待发送的消息超过了队列的容量。
+	ErrClientQueueFull = errors.New("websocket client send queue full")
+)
+
+// ClientState 表示客户端的连接状态。
+type ClientState int32
+
+const (
+	// StateConnecting 正在建立连接。
+	StateConnecting ClientState = iota
+	// StateConnected 连接已建立。
+	StateConnected
+	// StateDisconnected 连接断开，等待重新连接。
+	StateDisconnected
+	// StateClosed 客户端已关闭，不会再重新连接。
+	StateClosed
 )
 
+func (s ClientState) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	case StateClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
 // Client 表示 WebSocket 客户端，在kafka中消费。
 //
 // 该接口定义了 WebSocket 客户端应实现的方法，包括关闭连接、发送消息和读取消息。
+// 客户端在后台维护连接，断线后会自动重连，断线期间发送的消息会在重连后发出。
 type Client interface {
 	Close() error
 
 	Send(v any) error
+	SendCtx(ctx context.Context, v any) error
 	Read(v any) error
+
+	State() ClientState
 }
 
+// client 后台维护连接的 WebSocket 客户端。
+//
+// 字段:
+//   - state: 当前的连接状态。
+//   - sendQueue: 待发送的消息，断线期间的消息会保留在队列中。
+//   - readQueue: 接收到的消息，等待 Read 读取。
+//   - ping: 编码后的心跳消息。
+//   - done: 关闭客户端时的信号通道。
+//   - stopped: 后台连接维护结束时的信号通道。
 type client struct {
-	*websocket.Conn
 	host string
+	opt  dailOption
+
+	state atomic.Int32
+
+	sendQueue chan *clientMsg
+	readQueue chan []byte
+	ping      []byte
+
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+
+	logx.Logger
+}
 
-	opt dailOption
+// clientMsg 待发送的消息，written 不为 nil 时在消息写入连接后通知 SendCtx。
+type clientMsg struct {
+	data    []byte
+	written chan struct{}
 }
 
 // NewClient 创建一个新的 WebSocket 客户端。
 //
-// 该函数用于创建一个新的 WebSocket 客户端实例，初始化连接到指定的 WebSocket 服务器。
-// 如果连接失败，会导致程序 panic。
+// 该函数用于创建一个新的 WebSocket 客户端实例，连接在后台建立，连接失败时会按照指数退避的方式重试，
+// 因此创建客户端时不需要服务端已经启动。
 //
 // 参数:
 //   - host: WebSocket 服务器的主机地址。
@@ -37,19 +108,24 @@ type client struct {
 func NewClient(host string, opts ...DailOptions) *client {
 	opt := newDailOptions(opts...)
 
-	c := client{
-		Conn: nil,
-		host: host,
-		opt:  opt,
+	c := &client{
+		host:      host,
+		opt:       opt,
+		sendQueue: make(chan *clientMsg, opt.queueSize),
+		readQueue: make(chan []byte, opt.queueSize),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+		Logger:    logx.WithContext(context.Background()),
 	}
 
-	conn, err := c.dail()
+	ping, err := opt.codec.Marshal(&Message{FrameType: FramePing})
 	if err != nil {
 		panic(err)
 	}
+	c.ping = ping
 
-	c.Conn = conn
-	return &c
+	go c.run()
+	return c
 }
 
 // dial 与 WebSocket 服务器建立连接。
@@ -62,53 +138,308 @@ func NewClient(host string, opts ...DailOptions) *client {
 //   - error: 连接过程中发生的错误（如果有的话）。
 func (c *client) dail() (*websocket.Conn, error) {
 	u := url.URL{Scheme: "ws", Host: c.host, Path: c.opt.pattern}
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), c.opt.header)
+	dialer := websocket.DefaultDialer
+	if c.opt.tlsConfig != nil {
+		u.Scheme = "wss"
+		d := *websocket.DefaultDialer
+		d.TLSClientConfig = c.opt.tlsConfig
+		dialer = &d
+	}
+
+	header, err := c.opt.dailHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, _, err := dialer.Dial(u.String(), header)
 	return conn, err
 }
 
-// Send 序列化并发送消息到 WebSocket。
+// Send 序列化消息并放入发送队列。
 //
-// 该方法将消息对象序列化为 JSON 格式，并通过 WebSocket 连接发送。
-// 如果发送失败，会尝试重新连接并重新发送消息。
+// 该方法使用配置的编解码器序列化消息对象，消息由后台的连接按顺序发送。
+// 断线期间消息会保留在队列中，重连后继续发送。
 //
 // 参数:
 //   - v: 要发送的消息对象，可以是任意类型。
 //
 // 返回:
-//   - error: 发送消息过程中发生的错误（如果有的话）。
+//   - error: 序列化失败、队列已满或客户端已关闭时返回错误。
 func (c *client) Send(v any) error {
-	data, err := json.Marshal(v)
+	_, err := c.enqueue(v, false)
+	return err
+}
+
+// SendCtx 序列化消息并放入发送队列，等待消息写入连接后返回。
+//
+// 与 Send 不同，断线、重连失败等导致消息未能发出时调用方可以得知并重试。
+// ctx 结束时返回 ctx 的错误，消息仍保留在队列中，之后可能被发送，接收方需要能够处理重复的消息。
+//
+// 参数:
+//   - ctx: 等待消息写入的上下文。
+//   - v: 要发送的消息对象，可以是任意类型。
+//
+// 返回:
+//   - error: 序列化失败、队列已满、客户端已关闭或 ctx 结束时返回错误。
+func (c *client) SendCtx(ctx context.Context, v any) error {
+	msg, err := c.enqueue(v, true)
 	if err != nil {
 		return err
 	}
-	err = c.WriteMessage(websocket.TextMessage, data)
-	if err == nil {
+
+	select {
+	case <-msg.written:
 		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.stopped:
+		// 关闭前可能已经发送了队列中的消息
+		select {
+		case <-msg.written:
+			return nil
+		default:
+			return ErrClientClosed
+		}
 	}
-	// todo: 再增加一个重连发送
-	conn, err := c.dail()
+}
+
+// enqueue 序列化消息并放入发送队列，wait 为 true 时消息写入连接后关闭 written。
+func (c *client) enqueue(v any, wait bool) (*clientMsg, error) {
+	data, err := c.opt.codec.Marshal(v)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	msg := &clientMsg{data: data}
+	if wait {
+		msg.written = make(chan struct{})
+	}
+
+	select {
+	case <-c.done:
+		return nil, ErrClientClosed
+	default:
+	}
+
+	select {
+	case c.sendQueue <- msg:
+		return msg, nil
+	case <-c.done:
+		return nil, ErrClientClosed
+	default:
+		return nil, ErrClientQueueFull
 	}
-	c.Conn = conn
-	return c.WriteMessage(websocket.TextMessage, data)
 }
 
 // Read 从 WebSocket 读取消息并反序列化。
 //
-// 该方法从 WebSocket 连接中读取消息，并将其反序列化为指定的对象类型。
-// 如果读取或反序列化过程中发生错误，则返回错误。
+// 该方法阻塞等待后台连接接收到的消息，并使用配置的编解码器将其反序列化为指定的对象类型。
+// 心跳消息不会被返回。
 //
 // 参数:
 //   - v: 用于接收反序列化后的消息对象，可以是任意类型。
 //
 // 返回:
-//   - error: 读取消息过程中发生的错误（如果有的话）
+//   - error: 客户端已关闭或反序列化过程中发生的错误（如果有的话）
 func (c *client) Read(v any) error {
-	_, msg, err := c.Conn.ReadMessage()
-	if err != nil {
+	select {
+	case data := <-c.readQueue:
+		return c.opt.codec.Unmarshal(data, v)
+	case <-c.done:
+		return ErrClientClosed
+	}
+}
+
+// State 返回客户端当前的连接状态。
+func (c *client) State() ClientState {
+	return ClientState(c.state.Load())
+}
+
+// Close 关闭客户端。
+//
+// 如果连接正常，会先将队列中的消息发送完成，再发送关闭帧关闭连接。
+func (c *client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	<-c.stopped
+	return nil
+}
+
+// setState 更新连接状态并通知状态处理函数。
+func (c *client) setState(state ClientState, err error) {
+	if ClientState(c.state.Swap(int32(state))) == state && err == nil {
+		return
+	}
+	if c.opt.stateHandler != nil {
+		c.opt.stateHandler(state, err)
+	}
+}
+
+// run 在后台维护连接，连接断开后按照指数退避的方式重新连接。
+func (c *client) run() {
+	defer close(c.stopped)
+	defer c.setState(StateClosed, nil)
+
+	var (
+		pending *clientMsg
+		retries int
+	)
+	for {
+		c.setState(StateConnecting, nil)
+		conn, err := c.dail()
+		if err != nil {
+			retries++
+			c.Errorf("websocket client dail %v err %v, retries %v", c.host, err, retries)
+			if c.opt.maxRetries > 0 && retries >= c.opt.maxRetries {
+				c.closeOnce.Do(func() {
+					close(c.done)
+				})
+				return
+			}
+
+			c.setState(StateDisconnected, err)
+			if !c.wait(c.backoff(retries)) {
+				return
+			}
+			continue
+		}
+
+		retries = 0
+		c.setState(StateConnected, nil)
+
+		pending, err = c.serve(conn, pending)
+		if err == nil {
+			return
+		}
+		c.Errorf("websocket client %v disconnected err %v", c.host, err)
+		c.setState(StateDisconnected, err)
+	}
+}
+
+// serve 在连接上发送队列中的消息及心跳，直到连接断开或客户端关闭。
+//
+// 参数:
+//   - conn: 已建立的连接。
+//   - pending: 上一个连接发送失败的消息，会最先发送。
+//
+// 返回:
+//   - *clientMsg: 发送失败的消息，在下一个连接上重新发送。
+//   - error: 连接断开的原因，客户端关闭时返回 nil。
+func (c *client) serve(conn *websocket.Conn, pending *clientMsg) (*clientMsg, error) {
+	readErr := make(chan error, 1)
+	go c.readLoop(conn, readErr)
+
+	ticker := time.NewTicker(c.opt.pingInterval)
+	defer ticker.Stop()
+
+	if pending != nil {
+		if err := c.write(conn, pending); err != nil {
+			conn.Close()
+			return pending, err
+		}
+	}
+
+	for {
+		select {
+		case <-c.done:
+			c.flush(conn)
+			msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+			conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+			conn.Close()
+			return nil, nil
+		case err := <-readErr:
+			conn.Close()
+			return nil, err
+		case msg := <-c.sendQueue:
+			if err := c.write(conn, msg); err != nil {
+				conn.Close()
+				return msg, err
+			}
+		case <-ticker.C:
+			if err := c.write(conn, &clientMsg{data: c.ping}); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+	}
+}
+
+// readLoop 读取连接上的消息，超过三个心跳周期没有收到任何消息则认为连接已经断开。
+func (c *client) readLoop(conn *websocket.Conn, readErr chan<- error) {
+	pongWait := 3 * c.opt.pingInterval
+	for {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			readErr <- err
+			return
+		}
+
+		var msg Message
+		if err := c.opt.codec.Unmarshal(data, &msg); err == nil && msg.FrameType == FramePing {
+			continue
+		}
+
+		select {
+		case c.readQueue <- data:
+		default:
+			// 没有读取消息的调用方时丢弃，避免阻塞心跳的检测
+			c.Infof("websocket client %v read queue full, drop message", c.host)
+		}
+	}
+}
+
+// write 在连接上写入一条消息，写入成功后通知等待的 SendCtx。
+func (c *client) write(conn *websocket.Conn, msg *clientMsg) error {
+	conn.SetWriteDeadline(time.Now().Add(c.opt.writeTimeout))
+	if err := conn.WriteMessage(c.opt.codec.MessageType(), msg.data); err != nil {
 		return err
 	}
+	if msg.written != nil {
+		close(msg.written)
+	}
+	return nil
+}
+
+// flush 关闭前尽可能发送队列中剩余的消息。
+func (c *client) flush(conn *websocket.Conn) {
+	for {
+		select {
+		case msg := <-c.sendQueue:
+			if err := c.write(conn, msg); err != nil {
+				c.Errorf("websocket client %v flush err %v", c.host, err)
+				return
+			}
+		default:
+			return
+		}
+	}
+}
 
-	return json.Unmarshal(msg, v)
+// backoff 计算第 retries 次重连前等待的时间，在指数退避的基础上增加随机抖动，避免大量客户端同时重连。
+func (c *client) backoff(retries int) time.Duration {
+	delay := c.opt.backoffMin
+	for i := 1; i < retries && delay < c.opt.backoffMax; i++ {
+		delay *= 2
+	}
+	if delay > c.opt.backoffMax {
+		delay = c.opt.backoffMax
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// wait 等待指定的时间，客户端关闭时返回 false。
+func (c *client) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-c.done:
+		return false
+	}
 }
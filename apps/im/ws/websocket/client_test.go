@@ -0,0 +1,124 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Reconnect(t *testing.T) {
+	srv, hs := newTestServer(t)
+
+	var (
+		mu     sync.Mutex
+		states []ClientState
+	)
+	header := http.Header{}
+	header.Set("X-User-Id", "u1")
+	c := NewClient(strings.TrimPrefix(hs.URL, "http://"),
+		WithClientPatten(""),
+		WithClientHeader(header),
+		WithClientBackoff(10*time.Millisecond, 50*time.Millisecond),
+		WithClientStateHandler(func(state ClientState, err error) {
+			mu.Lock()
+			states = append(states, state)
+			mu.Unlock()
+		}),
+	)
+	defer c.Close()
+
+	waitFor(t, func() bool { return c.State() == StateConnected && srv.GetConn("u1") != nil })
+
+	// 服务端断开连接后客户端自动重连
+	old := srv.GetConn("u1")
+	srv.Close(old)
+	waitFor(t, func() bool {
+		conn := srv.GetConn("u1")
+		return conn != nil && conn != old && c.State() == StateConnected
+	})
+
+	mu.Lock()
+	got := append([]ClientState(nil), states...)
+	mu.Unlock()
+	want := []ClientState{StateConnected, StateDisconnected, StateConnecting, StateConnected}
+	if len(got) < len(want) {
+		t.Fatalf("states = %v, want prefix %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("states = %v, want prefix %v", got, want)
+		}
+	}
+}
+
+func TestClient_MaxRetries(t *testing.T) {
+	c := NewClient("127.0.0.1:1",
+		WithClientBackoff(time.Millisecond, time.Millisecond),
+		WithClientMaxRetries(3),
+	)
+
+	waitFor(t, func() bool { return c.State() == StateClosed })
+	if err := c.Send(&Message{}); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Send() err = %v, want %v", err, ErrClientClosed)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() err %v", err)
+	}
+}
+
+func TestClient_SendCtx(t *testing.T) {
+	_, hs := newTestServer(t)
+
+	header := http.Header{}
+	header.Set("X-User-Id", "u1")
+	c := NewClient(strings.TrimPrefix(hs.URL, "http://"), WithClientPatten(""), WithClientHeader(header))
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := c.SendCtx(ctx, &Message{FrameType: FrameData}); err != nil {
+		t.Errorf("SendCtx() err %v", err)
+	}
+
+	// 无法连接时消息未写入，等待到 ctx 结束
+	unreachable := NewClient("127.0.0.1:1", WithClientBackoff(time.Millisecond, time.Millisecond))
+	defer unreachable.Close()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := unreachable.SendCtx(ctx, &Message{FrameType: FrameData}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendCtx() err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_HeaderFunc(t *testing.T) {
+	srv, hs := newTestServer(t)
+
+	var uid atomic.Value
+	uid.Store("")
+	c := NewClient(strings.TrimPrefix(hs.URL, "http://"),
+		WithClientPatten(""),
+		WithClientBackoff(10*time.Millisecond, 50*time.Millisecond),
+		WithClientHeaderFunc(func() (http.Header, error) {
+			header := http.Header{}
+			header.Set("X-User-Id", uid.Load().(string))
+			return header, nil
+		}),
+	)
+	defer c.Close()
+
+	// 连接被拒绝后重连时重新获取头部
+	time.Sleep(50 * time.Millisecond)
+	uid.Store("u1")
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+
+	// 令牌轮换后重连使用新的头部
+	uid.Store("u2")
+	srv.Close(srv.GetConn("u1"))
+	waitFor(t, func() bool { return srv.GetConn("u2") != nil })
+}
@@ -0,0 +1,203 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/mitchellh/mapstructure"
+	"google.golang.org/protobuf/proto"
+	"im-chat/easy-chat/apps/im/ws/websocket/framepb"
+)
+
+// Codec 定义了 WebSocket 消息的编解码方式。
+//
+// 客户端通过 Sec-WebSocket-Protocol 携带编解码器的名称与服务端协商，未协商时使用服务端默认的编解码器。
+type Codec interface {
+	// Name 编解码器的名称，同时作为 Sec-WebSocket-Protocol 的子协议名称。
+	Name() string
+	// MessageType 发送消息时使用的 WebSocket 消息类型，例如 websocket.TextMessage。
+	MessageType() int
+	// Marshal 将消息编码为字节数据。
+	Marshal(v any) ([]byte, error)
+	// Unmarshal 将字节数据解码到 v 中。
+	Unmarshal(data []byte, v any) error
+	// Bind 将 Unmarshal 得到的 Message.Data 解析到 v 中。
+	Bind(data any, v any) error
+}
+
+// ProtoMarshaler 消息体实现该接口后，protobuf 编解码器会使用二进制编码消息体，否则使用 JSON 编码。
+type ProtoMarshaler interface {
+	MarshalProto() ([]byte, error)
+}
+
+// ProtoUnmarshaler 消息体实现该接口后，protobuf 编解码器可以将二进制的消息体解析到该对象中。
+type ProtoUnmarshaler interface {
+	UnmarshalProto(data []byte) error
+}
+
+var (
+	// JSONCodec 使用 JSON 文本编码消息，与原有的协议保持一致。
+	JSONCodec Codec = jsonCodec{}
+	// ProtoCodec 使用 protobuf 二进制编码消息，适用于对流量及性能敏感的移动端。
+	ProtoCodec Codec = protoCodec{}
+)
+
+var (
+	codecMu sync.RWMutex
+	codecs  = make(map[string]Codec)
+)
+
+func init() {
+	RegisterCodec(JSONCodec)
+	RegisterCodec(ProtoCodec)
+}
+
+// RegisterCodec 注册编解码器，注册后客户端可以通过 Sec-WebSocket-Protocol 协商使用。
+func RegisterCodec(codec Codec) {
+	codecMu.Lock()
+	defer codecMu.Unlock()
+
+	codecs[codec.Name()] = codec
+}
+
+// GetCodec 根据名称获取已注册的编解码器，不存在时返回 nil。
+func GetCodec(name string) Codec {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+
+	return codecs[name]
+}
+
+// negotiateCodec 根据请求携带的子协议协商编解码器。
+//
+// 参数:
+//   - r: HTTP 请求对象。
+//
+// 返回:
+//   - Codec: 第一个已注册的子协议对应的编解码器，没有时返回 nil。
+func negotiateCodec(r *http.Request) Codec {
+	for _, protocol := range websocket.Subprotocols(r) {
+		if codec := GetCodec(protocol); codec != nil {
+			return codec
+		}
+	}
+	return nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return "json" }
+
+func (jsonCodec) MessageType() int { return websocket.TextMessage }
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Bind(data any, v any) error {
+	return mapstructure.Decode(data, v)
+}
+
+type protoCodec struct{}
+
+// protoData protobuf 编解码器解析得到的消息体，在 Bind 时才解析为具体的类型。
+type protoData struct {
+	encoding framepb.Encoding
+	data     []byte
+}
+
+func (protoCodec) Name() string { return "protobuf" }
+
+func (protoCodec) MessageType() int { return websocket.BinaryMessage }
+
+func (c protoCodec) Marshal(v any) ([]byte, error) {
+	switch msg := v.(type) {
+	case *Message:
+		return c.marshalMessage(msg)
+	case Message:
+		return c.marshalMessage(&msg)
+	case proto.Message:
+		return proto.Marshal(msg)
+	}
+	return nil, fmt.Errorf("protobuf codec marshal unsupported type %T", v)
+}
+
+func (protoCodec) marshalMessage(msg *Message) ([]byte, error) {
+	pb := &framepb.Message{
+		FrameType: uint32(msg.FrameType),
+		Id:        msg.Id,
+		AckSeq:    int64(msg.AckSeq),
+		Method:    msg.Method,
+		FormId:    msg.FormId,
+	}
+
+	var err error
+	switch data := msg.Data.(type) {
+	case nil:
+	case protoData:
+		pb.Encoding, pb.Data = data.encoding, data.data
+	case ProtoMarshaler:
+		pb.Data, err = data.MarshalProto()
+	case proto.Message:
+		pb.Data, err = proto.Marshal(data)
+	default:
+		pb.Encoding = framepb.Encoding_JSON
+		pb.Data, err = json.Marshal(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(pb)
+}
+
+func (protoCodec) Unmarshal(data []byte, v any) error {
+	switch msg := v.(type) {
+	case *Message:
+		var pb framepb.Message
+		if err := proto.Unmarshal(data, &pb); err != nil {
+			return err
+		}
+
+		msg.FrameType = FrameType(pb.FrameType)
+		msg.Id = pb.Id
+		msg.AckSeq = int(pb.AckSeq)
+		msg.Method = pb.Method
+		msg.FormId = pb.FormId
+		msg.Data = nil
+		if len(pb.Data) > 0 {
+			msg.Data = protoData{encoding: pb.Encoding, data: pb.Data}
+		}
+		return nil
+	case proto.Message:
+		return proto.Unmarshal(data, msg)
+	}
+	return fmt.Errorf("protobuf codec unmarshal unsupported type %T", v)
+}
+
+func (protoCodec) Bind(data any, v any) error {
+	d, ok := data.(protoData)
+	if !ok {
+		// 进程内构造的消息，消息体仍然是原始的对象
+		return mapstructure.Decode(data, v)
+	}
+
+	if d.encoding == framepb.Encoding_JSON {
+		return json.Unmarshal(d.data, v)
+	}
+
+	switch dst := v.(type) {
+	case ProtoUnmarshaler:
+		return dst.UnmarshalProto(d.data)
+	case proto.Message:
+		return proto.Unmarshal(d.data, dst)
+	}
+	return fmt.Errorf("protobuf codec bind unsupported type %T", v)
+}
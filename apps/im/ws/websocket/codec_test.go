@@ -0,0 +1,78 @@
+package websocket
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/pkg/constants"
+)
+
+func TestCodec_Bind(t *testing.T) {
+	push := ws.Push{
+		ConversationId: "c1",
+		ChatType:       constants.GroupChatType,
+		SendId:         "u1",
+		RecvIds:        []string{"u2", "u3"},
+		SendTime:       1700000000000,
+		MsgId:          "m1",
+		ReadRecords:    map[string]string{"u1": "1"},
+		MType:          constants.TextMType,
+		Content:        "hello",
+	}
+
+	for _, codec := range []Codec{JSONCodec, ProtoCodec} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			data, err := codec.Marshal(&Message{FrameType: FrameData, Id: "1", Method: "push", Data: &push})
+			if err != nil {
+				t.Fatalf("Marshal() err %v", err)
+			}
+
+			var msg Message
+			if err := codec.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("Unmarshal() err %v", err)
+			}
+			if msg.Id != "1" || msg.Method != "push" {
+				t.Errorf("Unmarshal() = %+v", msg)
+			}
+
+			var got ws.Push
+			if err := codec.Bind(msg.Data, &got); err != nil {
+				t.Fatalf("Bind() err %v", err)
+			}
+			if !reflect.DeepEqual(got, push) {
+				t.Errorf("Bind() = %+v, want %+v", got, push)
+			}
+		})
+	}
+}
+
+func TestServer_NegotiateCodec(t *testing.T) {
+	srv, hs := newTestServer(t)
+
+	c := NewClient(strings.TrimPrefix(hs.URL, "http://"),
+		WithClientPatten(""),
+		WithClientHeader(map[string][]string{"X-User-Id": {"u1"}}),
+		WithClientCodec(ProtoCodec),
+	)
+	t.Cleanup(func() { c.Close() })
+
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+	if codec := srv.GetConn("u1").Codec(); codec != ProtoCodec {
+		t.Fatalf("negotiated codec = %v, want %v", codec.Name(), ProtoCodec.Name())
+	}
+
+	if err := srv.SendByUserId(NewMessage("u2", "hello"), "u1"); err != nil {
+		t.Fatalf("SendByUserId err %v", err)
+	}
+
+	var msg Message
+	if err := c.Read(&msg); err != nil {
+		t.Fatalf("Read err %v", err)
+	}
+	var data string
+	if err := ProtoCodec.Bind(msg.Data, &data); err != nil || data != "hello" {
+		t.Errorf("Bind() = %v, %v, want hello", data, err)
+	}
+}
@@ -1,12 +1,29 @@
 package websocket
 
 import (
-	"github.com/gorilla/websocket"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// Transport 连接底层的传输方式，*websocket.Conn 即为一种实现，HTTP 回退（SSE、长轮询）为另一种实现。
+//
+// 处理函数只使用 Conn，不关心连接使用的传输方式。
+type Transport interface {
+	// ReadMessage 读取客户端发送的一条消息。
+	ReadMessage() (messageType int, p []byte, err error)
+	// WriteMessage 向客户端写入一条消息。
+	WriteMessage(messageType int, data []byte) error
+	// WriteControl 写入控制消息，例如携带关闭码的关闭消息。
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	// SetWriteDeadline 设置写入消息的截止时间。
+	SetWriteDeadline(t time.Time) error
+	// Close 关闭底层的连接。
+	Close() error
+}
+
 // Conn 表示 WebSocket 连接。
 //
 // 该结构体定义了一个WebSocket连接的主要属性和状态，包括用户ID、WebSocket连接实例、
@@ -16,41 +33,88 @@ import (
 // 字段:
 //   - idleMu: 连接空闲状态的互斥锁，用于保护空闲时间的读写操作。
 //   - Uid: 用户标识符，用于标识与该连接关联的用户。
-//   - websocket.Conn: WebSocket连接实例，表示与客户端的实际WebSocket连接。
+//   - DeviceId: 设备标识符，同一用户的多个设备通过该字段区分。
+//   - DeviceType: 设备类型，用于多端登录的踢出策略。
+//   - Version: 客户端的版本，用于按版本广播消息，未携带时为空。
+//   - ConnectAt: 连接建立的时间。
+//   - RemoteAddr: 客户端的地址，经过代理时使用 X-Forwarded-For 中的第一个地址。
+//   - Transport: 连接底层的传输方式，例如 WebSocket 连接或 HTTP 回退的会话。
 //   - s: 连接所属的WebSocket服务器，用于访问服务器相关的功能和状态。
+//   - codec: 连接协商得到的编解码器，用于消息的编码和解码。
 //   - idle: 连接的空闲时间，用于检测连接的活动状态。
 //   - maxConnectionIdle: 允许的最大空闲时间，超过该时间连接将被认为是超时。
 //   - messageMu: 消息队列的互斥锁，用于保护消息队列的读写操作。
-//   - readMessage: 读消息队列，存储尚未处理的消息。
-//   - readMessageSeq: 读消息队列的序列化映射，用于按序号存储消息。
+//   - acks: 需要确认的消息，包括等待客户端确认及已确认但尚未处理完成的消息。
+//   - ackBacklog: 确认窗口已满时排队等待的消息。
+//   - ackOutstanding: 等待客户端确认的消息数。
 //   - message: 消息通道，用于接收和发送消息。
+//   - inflight: 已交给处理函数但尚未处理完成的消息数，用于停止服务时等待消息处理完成。
+//   - outbound: 发送队列，存储编码后等待写入连接的消息。
+//   - unsent: 已放入发送队列但尚未写入完成的消息数。
+//   - slow: 作为慢消费者正在被断开，避免重复断开连接。
+//   - pushes: 服务端推送且等待客户端确认的消息，连接关闭后为 nil。
+//   - token: 连接当前使用的令牌，鉴权实现 TokenAuthentication 时记录。
+//   - warnItem、expireItem: 令牌即将过期的提醒及过期后关闭连接的定时任务。
 //   - done: 关闭连接时的信号通道，用于通知连接的结束。
 type Conn struct {
 	idleMu sync.Mutex
 
-	Uid string
+	Uid        string
+	DeviceId   string
+	DeviceType DeviceType
+	Version    string
+	ConnectAt  time.Time
+	RemoteAddr string
+	// Internal 为 true 时是内部服务的连接，见 WithServerInternalUsers
+	Internal bool
+	// PushAck 为 true 时客户端在握手时声明会确认推送的消息，见 SendWithAck
+	PushAck bool
 
-	*websocket.Conn
-	s *Server
+	Transport
+	s     *Server
+	codec Codec
 
 	idle              time.Time
 	maxConnectionIdle time.Duration
 
 	messageMu      sync.Mutex
-	readMessage    []*Message
-	readMessageSeq map[string]*Message
+	acks           map[string]*ackEntry
+	ackBacklog     []*Message
+	ackOutstanding int
 
 	message chan *Message
+	// 已交给处理函数但尚未处理完成的消息数
+	inflight int32
+
+	outbound chan []byte
+	// 已放入发送队列但尚未写入完成的消息数
+	unsent int32
+	// 作为慢消费者正在被断开时为 1
+	slow int32
+
+	pushMu sync.Mutex
+	pushes map[string]*pendingPush
+
+	authMu     sync.Mutex
+	token      TokenInfo
+	warnItem   *ackItem
+	expireItem *ackItem
 
 	done chan struct{}
 }
 
 func NewConn(s *Server, w http.ResponseWriter, r *http.Request) *Conn {
 
+	// 协商编解码器，未携带编解码器的子协议时沿用原有的处理方式
+	codec := negotiateCodec(r)
 	var responseHeader http.Header
-	if protocol := r.Header.Get("Sec-WebSocket-Protocol"); protocol != "" {
-		responseHeader = http.Header{"Sec-WebSocket-Protocol": []string{protocol}}
-
+	if codec != nil {
+		responseHeader = http.Header{"Sec-WebSocket-Protocol": []string{codec.Name()}}
+	} else {
+		codec = s.opt.codec
+		if protocol := r.Header.Get("Sec-WebSocket-Protocol"); protocol != "" {
+			responseHeader = http.Header{"Sec-WebSocket-Protocol": []string{protocol}}
+		}
 	}
 
 	c, err := s.upgrader.Upgrade(w, r, responseHeader)
@@ -59,59 +123,144 @@ func NewConn(s *Server, w http.ResponseWriter, r *http.Request) *Conn {
 		return nil
 	}
 
+	return newConn(s, c, codec, r)
+}
+
+// newConn 使用底层的传输方式创建连接，设备信息从请求中获取。
+func newConn(s *Server, transport Transport, codec Codec, r *http.Request) *Conn {
+	deviceId, deviceType := parseDevice(r)
+
 	conn := &Conn{
-		Conn:              c,
+		Transport:         transport,
 		s:                 s,
+		DeviceId:          deviceId,
+		DeviceType:        deviceType,
+		Version:           parseVersion(r),
+		PushAck:           parsePushAck(r),
+		ConnectAt:         time.Now(),
+		RemoteAddr:        remoteAddr(r),
+		codec:             codec,
 		idle:              time.Now(),
 		maxConnectionIdle: s.opt.maxConnectionIdle,
-		readMessage:       make([]*Message, 0, 2),
-		readMessageSeq:    make(map[string]*Message, 2),
+		acks:              make(map[string]*ackEntry, 2),
 		message:           make(chan *Message, 1),
+		outbound:          make(chan []byte, s.opt.sendQueueSize),
+		pushes:            make(map[string]*pendingPush),
 		done:              make(chan struct{}),
 	}
 
-	go conn.keepalive()
 	return conn
 }
 
-// appendMsgMq 将消息添加到消息队列中。
-//
-// 该方法用于将传入的消息添加到读消息队列中，并维护消息序列化映射。
-// 如果消息已经存在于队列中且其确认序号小于等于之前存储的消息，则忽略该消息。
-// 如果消息类型是确认消息（FrameAck），则不处理。
-// 否则，将消息添加到队列并更新消息序列化映射。
+// remoteAddr 获取客户端的地址，经过代理时使用 X-Forwarded-For 中的第一个地址。
+func remoteAddr(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		addr, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(addr)
+	}
+	return r.RemoteAddr
+}
+
+// ConnInfo 连接的状态信息，用于管理接口查看节点上的连接。
 //
-// 参数:
-//   - msg: 要添加到消息队列中的消息结构体。
-func (c *Conn) appendMsgMq(msg *Message) {
+// 字段:
+//   - Uid、DeviceId、DeviceType: 连接的用户及设备。
+//   - Version: 客户端的版本。
+//   - RemoteAddr: 客户端的地址。
+//   - Transport: 连接的传输方式，websocket 或 http（HTTP 回退）。
+//   - ConnectAt: 连接建立的时间。
+//   - IdleMs: 连接的空闲时间（毫秒），正在接收客户端消息时为 0。
+//   - PendingAcks: 等待确认及处理的客户端消息数（RigorAck）。
+//   - PendingPushes: 等待客户端确认的推送数。
+//   - Unsent: 发送队列中尚未写入的消息数。
+type ConnInfo struct {
+	Uid           string     `json:"uid"`
+	DeviceId      string     `json:"deviceId"`
+	DeviceType    DeviceType `json:"deviceType"`
+	Version       string     `json:"version"`
+	RemoteAddr    string     `json:"remoteAddr"`
+	Transport     string     `json:"transport"`
+	ConnectAt     time.Time  `json:"connectAt"`
+	IdleMs        int64      `json:"idleMs"`
+	PendingAcks   int        `json:"pendingAcks"`
+	PendingPushes int        `json:"pendingPushes"`
+	Unsent        int32      `json:"unsent"`
+}
+
+// Info 返回连接当前的状态信息。
+func (c *Conn) Info() ConnInfo {
+	info := ConnInfo{
+		Uid:        c.Uid,
+		DeviceId:   c.DeviceId,
+		DeviceType: c.DeviceType,
+		Version:    c.Version,
+		RemoteAddr: c.RemoteAddr,
+		Transport:  "websocket",
+		ConnectAt:  c.ConnectAt,
+		Unsent:     atomic.LoadInt32(&c.unsent),
+	}
+	if _, ok := c.Transport.(*httpTransport); ok {
+		info.Transport = "http"
+	}
+
+	c.idleMu.Lock()
+	if !c.idle.IsZero() {
+		info.IdleMs = time.Since(c.idle).Milliseconds()
+	}
+	c.idleMu.Unlock()
+
 	c.messageMu.Lock()
-	defer c.messageMu.Unlock()
+	info.PendingAcks = len(c.acks) + len(c.ackBacklog)
+	c.messageMu.Unlock()
 
-	// 读队列中
-	if m, ok := c.readMessageSeq[msg.Id]; ok {
-		// 已经有消息的记录，该消息已经有ack的确认
-		if len(c.readMessage) == 0 {
-			// 队列中没有该消息
-			return
-		}
+	c.pushMu.Lock()
+	info.PendingPushes = len(c.pushes)
+	c.pushMu.Unlock()
 
-		// msg.AckSeq > m.AckSeq
-		if m.AckSeq >= msg.AckSeq {
-			// 没有进行ack的确认, 重复
-			return
-		}
+	return info
+}
 
-		c.readMessageSeq[msg.Id] = msg
-		return
-	}
-	// 还没有进行ack的确认, 避免客户端重复发送多余的ack消息
-	if msg.FrameType == FrameAck {
-		return
+// Codec 返回连接使用的编解码器。
+func (c *Conn) Codec() Codec {
+	return c.codec
+}
+
+// Bind 使用连接的编解码器将消息体解析到 v 中。
+//
+// 参数:
+//   - msg: 接收到的消息。
+//   - v: 用于接收消息体的对象指针，例如 *ws.Chat。
+//
+// 返回:
+//   - error: 解析过程中发生的错误（如果有的话）。
+func (c *Conn) Bind(msg *Message, v any) error {
+	return c.codec.Bind(msg.Data, v)
+}
+
+// dispatch 将消息交给处理函数处理。
+//
+// 该方法将消息放入消息通道并记录待处理的消息数，如果连接已经关闭，则丢弃该消息。
+//
+// 参数:
+//   - msg: 需要处理的消息。
+func (c *Conn) dispatch(msg *Message) {
+	atomic.AddInt32(&c.inflight, 1)
+	select {
+	case c.message <- msg:
+	case <-c.done:
+		atomic.AddInt32(&c.inflight, -1)
 	}
+}
 
-	c.readMessage = append(c.readMessage, msg)
-	c.readMessageSeq[msg.Id] = msg
+// drained 判断连接中所有已接收的消息是否都已处理完成。
+//
+// 返回:
+//   - bool: 待确认的消息队列、发送队列为空且没有正在处理的消息时返回 true。
+func (c *Conn) drained() bool {
+	c.messageMu.Lock()
+	defer c.messageMu.Unlock()
 
+	return len(c.acks) == 0 && len(c.ackBacklog) == 0 && atomic.LoadInt32(&c.inflight) == 0 && atomic.LoadInt32(&c.unsent) == 0
 }
 
 // ReadMessage 从 WebSocket 连接中读取消息。
@@ -125,7 +274,7 @@ func (c *Conn) appendMsgMq(msg *Message) {
 //   - p: 读取到的消息内容。
 //   - err: 读取消息时发生的错误，如果没有错误则返回nil。
 func (c *Conn) ReadMessage() (messageType int, p []byte, err error) {
-	messageType, p, err = c.Conn.ReadMessage()
+	messageType, p, err = c.Transport.ReadMessage()
 
 	c.idleMu.Lock()
 	defer c.idleMu.Unlock()
@@ -149,7 +298,7 @@ func (c *Conn) WriteMessage(messageType int, data []byte) error {
 	c.idleMu.Lock()
 	defer c.idleMu.Unlock()
 	// 方法是并不安全，加锁
-	err := c.Conn.WriteMessage(messageType, data)
+	err := c.Transport.WriteMessage(messageType, data)
 	c.idle = time.Now()
 	return err
 }
@@ -168,7 +317,7 @@ func (c *Conn) Close() error {
 	default:
 		close(c.done)
 	}
-	return c.Conn.Close()
+	return c.Transport.Close()
 }
 
 // keepalive 定期检查连接的空闲状态，确保连接在超过最大空闲时间后被优雅地关闭。
@@ -176,14 +325,42 @@ func (c *Conn) Close() error {
 // 如果连接空闲时间超过了最大空闲时间，连接将被关闭。
 // 如果连接未超过空闲时间，定时器将重置以继续监控连接状态。
 // 方法会监听连接的关闭事件，以便在连接关闭时终止检查。
+// 如果服务器配置了 Locator 或 Presence，还会定期刷新连接记录的过期时间。
 func (c *Conn) keepalive() {
 	idleTimer := time.NewTimer(c.maxConnectionIdle)
 	defer func() {
 		idleTimer.Stop()
 	}()
 
+	// 定期刷新连接在 Locator 及 Presence 中的记录，避免记录过期
+	var refresh <-chan time.Time
+	if !c.Internal && (c.s.opt.locator != nil || c.s.opt.presence != nil) {
+		refreshTicker := time.NewTicker(c.s.opt.locatorRefresh)
+		defer refreshTicker.Stop()
+		refresh = refreshTicker.C
+	}
+
+	// 定期检查令牌是否已被吊销
+	var revoke <-chan time.Time
+	if _, ok := c.s.tokenAuth(); ok && c.s.opt.tokenCheck > 0 {
+		revokeTicker := time.NewTicker(c.s.opt.tokenCheck)
+		defer revokeTicker.Stop()
+		revoke = revokeTicker.C
+	}
+
 	for {
 		select {
+		case <-revoke:
+			if c.s.checkRevoked(c) {
+				return
+			}
+		case <-refresh:
+			if c.s.opt.locator != nil {
+				if err := c.s.opt.locator.Refresh(c.Uid, c.DeviceId, c.s.opt.node); err != nil {
+					c.s.Errorf("locator refresh uid %v err %v", c.Uid, err)
+				}
+			}
+			c.s.presenceOnline(c)
 		case <-idleTimer.C:
 			c.idleMu.Lock()
 			idle := c.idle
@@ -197,6 +374,7 @@ func (c *Conn) keepalive() {
 			if val <= 0 {
 				// The connection has been idle for a duration of keepalive.MaxConnectionIdle or more.
 				// Gracefully close the connection.
+				metricIdleDisconnects.Inc()
 				c.s.Close(c)
 				return
 			}
@@ -1,6 +1,10 @@
 package websocket
 
-import "net/http"
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
 
 // DailOptions 定义了用于配置拨号选项的函数类型。
 //
@@ -11,8 +15,24 @@ type DailOptions func(option *dailOption)
 //
 // 该结构体包括连接的 HTTP 头部和连接路径模式等设置。
 type dailOption struct {
-	pattern string
-	header  http.Header
+	pattern    string
+	header     http.Header
+	headerFunc func() (http.Header, error)
+
+	deviceId   string
+	deviceType DeviceType
+
+	codec Codec
+
+	tlsConfig *tls.Config
+
+	pingInterval time.Duration
+	writeTimeout time.Duration
+	backoffMin   time.Duration
+	backoffMax   time.Duration
+	maxRetries   int
+	queueSize    int
+	stateHandler func(state ClientState, err error)
 }
 
 // newDialOptions 创建一个具有默认值的新的 dialOption 结构体，并根据传入的选项进行配置。
@@ -28,6 +48,13 @@ func newDailOptions(opts ...DailOptions) dailOption {
 	o := dailOption{
 		pattern: "/ws",
 		header:  nil,
+		codec:   JSONCodec,
+
+		pingInterval: defaultClientPingInterval,
+		writeTimeout: defaultClientWriteTimeout,
+		backoffMin:   defaultClientBackoffMin,
+		backoffMax:   defaultClientBackoffMax,
+		queueSize:    defaultClientQueueSize,
 	}
 
 	for _, opt := range opts {
@@ -66,3 +93,188 @@ func WithClientHeader(header http.Header) DailOptions {
 		opt.header = header
 	}
 }
+
+// WithClientHeaderFunc 返回一个在每次建立连接时获取 HTTP 头部的 DialOptions 函数。
+//
+// 每次连接及重连时调用 fn，适合会轮换的令牌，连接被拒绝后重连即使用新的令牌；fn 返回错误时本次连接失败，按重连策略重试。
+// 设置后 WithClientHeader 设置的头部不再生效。
+//
+// 参数:
+//   - fn: 获取 HTTP 头部的函数。
+//
+// 返回:
+//   - DialOptions: 配置 HTTP 头部的函数。
+func WithClientHeaderFunc(fn func() (http.Header, error)) DailOptions {
+	return func(opt *dailOption) {
+		opt.headerFunc = fn
+	}
+}
+
+// WithClientDevice 返回一个设置设备信息的 DialOptions 函数。
+//
+// 同一用户使用不同的设备标识建立的连接不会相互替换。
+//
+// 参数:
+//   - deviceId: 设备标识。
+//   - deviceType: 设备类型。
+//
+// 返回:
+//   - DialOptions: 配置设备信息的函数。
+func WithClientDevice(deviceId string, deviceType DeviceType) DailOptions {
+	return func(opt *dailOption) {
+		opt.deviceId = deviceId
+		opt.deviceType = deviceType
+	}
+}
+
+// WithClientCodec 返回一个设置编解码器的 DialOptions 函数。
+//
+// 除 JSONCodec 外，编解码器的名称会通过 Sec-WebSocket-Protocol 与服务端协商。
+//
+// 参数:
+//   - codec: 编解码器，例如 ProtoCodec。
+//
+// 返回:
+//   - DialOptions: 配置编解码器的函数。
+func WithClientCodec(codec Codec) DailOptions {
+	return func(opt *dailOption) {
+		if codec != nil {
+			opt.codec = codec
+		}
+	}
+}
+
+// WithClientHeartbeat 返回一个设置心跳间隔的 DialOptions 函数。
+//
+// 客户端按照该间隔发送 FramePing 消息，超过三个间隔没有收到服务端的任何消息时认为连接已经断开。
+//
+// 参数:
+//   - interval: 心跳间隔。
+//
+// 返回:
+//   - DialOptions: 配置心跳间隔的函数。
+func WithClientHeartbeat(interval time.Duration) DailOptions {
+	return func(opt *dailOption) {
+		if interval > 0 {
+			opt.pingInterval = interval
+		}
+	}
+}
+
+// WithClientBackoff 返回一个设置重连退避时间的 DialOptions 函数。
+//
+// 每次重连失败后等待的时间翻倍，直到 max 为止，实际等待的时间会在 [d/2, d] 之间随机。
+//
+// 参数:
+//   - min: 第一次重连前等待的时间。
+//   - max: 重连前等待的最长时间。
+//
+// 返回:
+//   - DialOptions: 配置重连退避时间的函数。
+func WithClientBackoff(min, max time.Duration) DailOptions {
+	return func(opt *dailOption) {
+		if min > 0 {
+			opt.backoffMin = min
+		}
+		if max >= opt.backoffMin {
+			opt.backoffMax = max
+		}
+	}
+}
+
+// WithClientMaxRetries 返回一个设置最大连续重连次数的 DialOptions 函数。
+//
+// 连续重连失败达到该次数后客户端自动关闭，默认为 0 表示一直重连。
+//
+// 参数:
+//   - retries: 最大连续重连次数。
+//
+// 返回:
+//   - DialOptions: 配置最大连续重连次数的函数。
+func WithClientMaxRetries(retries int) DailOptions {
+	return func(opt *dailOption) {
+		opt.maxRetries = retries
+	}
+}
+
+// WithClientQueueSize 返回一个设置消息队列容量的 DialOptions 函数。
+//
+// 断线期间待发送的消息超过该容量时 Send 返回 ErrClientQueueFull。
+//
+// 参数:
+//   - size: 消息队列的容量。
+//
+// 返回:
+//   - DialOptions: 配置消息队列容量的函数。
+func WithClientQueueSize(size int) DailOptions {
+	return func(opt *dailOption) {
+		if size > 0 {
+			opt.queueSize = size
+		}
+	}
+}
+
+// WithClientStateHandler 返回一个设置连接状态处理函数的 DialOptions 函数。
+//
+// 连接状态发生变化时会调用该函数，连接断开时 err 为断开的原因。
+//
+// 参数:
+//   - handler: 连接状态处理函数。
+//
+// 返回:
+//   - DialOptions: 配置连接状态处理函数的函数。
+func WithClientStateHandler(handler func(state ClientState, err error)) DailOptions {
+	return func(opt *dailOption) {
+		opt.stateHandler = handler
+	}
+}
+
+// WithClientTLS 返回一个使用 wss 连接服务器的 DialOptions 函数。
+//
+// 参数:
+//   - config: TLS 配置，为 nil 时使用默认配置（校验服务器证书）。
+//
+// 返回:
+//   - DialOptions: 配置 TLS 的函数。
+func WithClientTLS(config *tls.Config) DailOptions {
+	return func(opt *dailOption) {
+		if config == nil {
+			config = &tls.Config{}
+		}
+		opt.tlsConfig = config
+	}
+}
+
+// dailHeader 返回建立连接时使用的 HTTP 头部，包含设备信息及编解码器的子协议。
+func (o *dailOption) dailHeader() (http.Header, error) {
+	header := o.header
+	if o.headerFunc != nil {
+		var err error
+		if header, err = o.headerFunc(); err != nil {
+			return nil, err
+		}
+	}
+	if o.deviceId == "" && o.deviceType == UnknownDevice && o.codec == JSONCodec {
+		return header, nil
+	}
+
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if o.deviceId != "" {
+		header.Set("X-Device-Id", o.deviceId)
+	}
+	if o.deviceType != UnknownDevice {
+		header.Set("X-Device-Type", string(o.deviceType))
+	}
+	if o.codec != JSONCodec {
+		// 编解码器放在最前面，原有的子协议（例如令牌）保留在后面
+		protocol := o.codec.Name()
+		if exists := header.Get("Sec-WebSocket-Protocol"); exists != "" {
+			protocol += ", " + exists
+		}
+		header.Set("Sec-WebSocket-Protocol", protocol)
+	}
+	return header, nil
+}
@@ -8,6 +8,36 @@ import (
 const (
 	defaultMaxConnectionIdle = time.Duration(math.MaxInt64) //默认最大空闲时间
 	defaultAckTimeout        = 30 * time.Second
+	defaultAckRetryInterval  = 3 * time.Second
+	defaultAckWindow         = 16
 
-	defaultConcurrency = 10
+	defaultConcurrency    = 10
+	defaultBroadcastBatch = 500
+
+	defaultLocatorRefresh = 30 * time.Second
+
+	defaultShutdownTimeout = 5 * time.Second
+
+	defaultSendQueueSize = 256
+	defaultWriteTimeout  = 10 * time.Second
+
+	defaultPushAckTimeout = 5 * time.Second
+	defaultPushRetries    = 3
+
+	defaultFallbackIdle      = 30 * time.Second
+	defaultFallbackPoll      = 25 * time.Second
+	defaultFallbackHeartbeat = 15 * time.Second
+	defaultFallbackQueueSize = 64
+	defaultFallbackBodyLimit = 1 << 20
+
+	defaultTokenWarn  = time.Minute
+	defaultTokenCheck = 30 * time.Second
+
+	defaultClientPingInterval = 20 * time.Second
+	defaultClientWriteTimeout = 10 * time.Second
+	defaultClientBackoffMin   = 500 * time.Millisecond
+	defaultClientBackoffMax   = 30 * time.Second
+	defaultClientQueueSize    = 1024
+
+	defaultDispatcherMaxRetries = 5
 )
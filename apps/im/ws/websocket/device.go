@@ -0,0 +1,106 @@
+package websocket
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// DeviceType 表示客户端的设备类型，用于多端登录时的踢出策略。
+type DeviceType string
+
+const (
+	UnknownDevice DeviceType = ""
+	MobileDevice  DeviceType = "mobile"
+	DesktopDevice DeviceType = "desktop"
+	WebDevice     DeviceType = "web"
+	PadDevice     DeviceType = "pad"
+)
+
+// defaultDeviceId 未携带设备标识的客户端统一视为同一个设备，保持单端登录的旧行为。
+const defaultDeviceId = "default"
+
+// parseDevice 从请求中解析设备信息。
+//
+// 优先读取 query 参数 deviceId、deviceType，其次读取请求头 X-Device-Id、X-Device-Type。
+//
+// 参数:
+//   - r: HTTP 请求对象。
+//
+// 返回:
+//   - string: 设备标识，未携带时返回 defaultDeviceId。
+//   - DeviceType: 设备类型，未携带时返回 UnknownDevice。
+func parseDevice(r *http.Request) (string, DeviceType) {
+	query := r.URL.Query()
+
+	deviceId := query.Get("deviceId")
+	if deviceId == "" {
+		deviceId = r.Header.Get("X-Device-Id")
+	}
+	if deviceId == "" {
+		deviceId = defaultDeviceId
+	}
+
+	deviceType := query.Get("deviceType")
+	if deviceType == "" {
+		deviceType = r.Header.Get("X-Device-Type")
+	}
+
+	return deviceId, DeviceType(deviceType)
+}
+
+// parseVersion 从请求中解析客户端版本，优先读取 query 参数 version，其次读取请求头 X-Client-Version。
+func parseVersion(r *http.Request) string {
+	if version := r.URL.Query().Get("version"); version != "" {
+		return version
+	}
+	return r.Header.Get("X-Client-Version")
+}
+
+// parsePushAck 从请求中解析客户端是否确认推送的消息，优先读取 query 参数 pushAck，其次读取请求头 X-Push-Ack。
+//
+// 只有声明支持的客户端才会收到需要确认的推送，旧版本的客户端不会回复确认，推送时不重发。
+func parsePushAck(r *http.Request) bool {
+	v := r.URL.Query().Get("pushAck")
+	if v == "" {
+		v = r.Header.Get("X-Push-Ack")
+	}
+	ok, _ := strconv.ParseBool(v)
+	return ok
+}
+
+// KickPolicy 定义了多端登录时的踢出策略。
+//
+// 参数:
+//   - conn: 新建立的连接。
+//   - exists: 该用户已经存在的连接（不包含同一设备标识的旧连接，该连接总会被替换）。
+//
+// 返回:
+//   - []*Conn: 需要被踢下线的连接。
+type KickPolicy func(conn *Conn, exists []*Conn) []*Conn
+
+// KickSingleDevice 单端登录，新连接会踢掉该用户其余所有的连接。
+func KickSingleDevice(conn *Conn, exists []*Conn) []*Conn {
+	return exists
+}
+
+// KickNone 不限制登录的设备数量，仅替换同一设备的旧连接。
+func KickNone(conn *Conn, exists []*Conn) []*Conn {
+	return nil
+}
+
+// KickSameDeviceType 同一类型的设备只保留一个连接，例如 "一个手机 + 一个电脑 + 一个网页"。
+//
+// 未知类型的设备不参与类型互踢，只会替换同一设备的旧连接。
+func KickSameDeviceType(conn *Conn, exists []*Conn) []*Conn {
+	if conn.DeviceType == UnknownDevice {
+		return nil
+	}
+
+	var res []*Conn
+	for _, c := range exists {
+		if c.DeviceType == conn.DeviceType {
+			res = append(res, c)
+		}
+	}
+	return res
+}
@@ -0,0 +1,229 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/utils"
+)
+
+// Dispatcher 依据 Locator 将消息路由到用户连接所在的 im.ws 节点。
+//
+// Dispatcher 为每个节点维护一个 WebSocket 客户端，客户端在首次向该节点发送消息时创建，
+// 断线后由客户端自动重连，连续重连失败的客户端会被关闭，下一次发送时重新创建。
+type Dispatcher struct {
+	mu sync.Mutex
+
+	locator Locator
+	opts    []DailOptions
+	clients map[string]Client
+
+	logx.Logger
+}
+
+// NewDispatcher 创建一个新的 Dispatcher。
+//
+// 每个 Dispatcher 使用独立的设备标识连接 im.ws，避免多个实例之间相互踢下线。
+// 默认连续重连 5 次失败后放弃该节点，可以通过 WithClientMaxRetries 修改。
+//
+// 参数:
+//   - locator: 用户节点记录。
+//   - opts: 连接各个节点时使用的拨号选项。
+//
+// 返回:
+//   - *Dispatcher: 新创建的 Dispatcher。
+func NewDispatcher(locator Locator, opts ...DailOptions) *Dispatcher {
+	return &Dispatcher{
+		locator: locator,
+		opts: append(append([]DailOptions{WithClientMaxRetries(defaultDispatcherMaxRetries)}, opts...),
+			WithClientDevice(utils.NewUuid(), UnknownDevice)),
+		clients: make(map[string]Client),
+		Logger:  logx.WithContext(context.Background()),
+	}
+}
+
+// Dispatch 将消息投递到 uids 所在的所有节点。
+//
+// build 根据节点及该节点上的用户构造需要发送的消息，返回 nil 时不向该节点发送（例如由当前节点直接投递），离线的用户会被忽略。
+// 某个节点投递失败不会影响其他节点，所有失败的节点会合并为一个错误返回。
+// 消息放入节点客户端的发送队列后即返回，不等待消息写入连接。
+//
+// 参数:
+//   - uids: 需要投递的用户 ID 列表。
+//   - build: 构造发送给指定节点的消息。
+//
+// 返回:
+//   - error: 投递过程中发生的错误（如果有的话）。
+func (d *Dispatcher) Dispatch(uids []string, build func(node string, uids []string) any) error {
+	return d.dispatch(uids, build, nil, d.send)
+}
+
+// DispatchCtx 与 Dispatch 相同，但等待消息写入各节点的连接后返回，不在任何节点上的用户交给 offline 处理，例如保存为离线消息。
+//
+// 节点断线或 ctx 结束前未能写入时返回错误，调用方可以重试；重试时已写入的节点会再次收到消息，接收方需要能够处理重复的消息。
+//
+// 参数:
+//   - ctx: 等待消息写入的上下文。
+//   - uids: 需要投递的用户 ID 列表。
+//   - build: 构造发送给指定节点的消息。
+//   - offline: 处理离线的用户，为 nil 时忽略离线的用户。
+//
+// 返回:
+//   - error: 投递或处理离线用户过程中发生的错误（如果有的话）。
+func (d *Dispatcher) DispatchCtx(ctx context.Context, uids []string, build func(node string, uids []string) any,
+	offline func(uids []string) error) error {
+	return d.dispatch(uids, build, offline, func(node string, msg any) error {
+		return d.sendCtx(ctx, node, msg)
+	})
+}
+
+func (d *Dispatcher) dispatch(uids []string, build func(node string, uids []string) any,
+	offline func(uids []string) error, send func(node string, msg any) error) error {
+	if len(uids) == 0 {
+		return nil
+	}
+
+	nodes, err := d.locator.Locate(uids...)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if offline != nil {
+		if offlineUids := offlineUids(uids, nodes); len(offlineUids) > 0 {
+			if err := offline(offlineUids); err != nil {
+				errs = append(errs, fmt.Errorf("dispatch offline %v err %w", offlineUids, err))
+			}
+		}
+	}
+	for node, nodeUids := range nodes {
+		msg := build(node, nodeUids)
+		if msg == nil {
+			continue
+		}
+		if err := send(node, msg); err != nil {
+			errs = append(errs, fmt.Errorf("dispatch to node %v err %w", node, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Broadcast 将消息投递到所有存活的节点，例如系统公告，由各个节点向其上的连接广播。
+//
+// build 根据节点构造需要发送的消息，返回 nil 时不向该节点发送。
+// 某个节点投递失败不会影响其他节点，所有失败的节点会合并为一个错误返回。
+//
+// 参数:
+//   - build: 构造发送给指定节点的消息。
+//
+// 返回:
+//   - int: 投递成功的节点数。
+//   - error: 投递过程中发生的错误（如果有的话）。
+func (d *Dispatcher) Broadcast(build func(node string) any) (int, error) {
+	nodes, err := d.locator.Nodes()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		sent int
+		errs []error
+	)
+	for _, node := range nodes {
+		msg := build(node)
+		if msg == nil {
+			continue
+		}
+		if err := d.send(node, msg); err != nil {
+			errs = append(errs, fmt.Errorf("broadcast to node %v err %w", node, err))
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// send 向指定节点发送消息，客户端已关闭或队列已满时移除该节点的客户端。
+func (d *Dispatcher) send(node string, msg any) error {
+	c := d.client(node)
+
+	err := c.Send(msg)
+	if errors.Is(err, ErrClientClosed) || errors.Is(err, ErrClientQueueFull) {
+		d.remove(node, c)
+	}
+	return err
+}
+
+// sendCtx 与 send 相同，等待消息写入节点的连接。
+func (d *Dispatcher) sendCtx(ctx context.Context, node string, msg any) error {
+	c := d.client(node)
+
+	err := c.SendCtx(ctx, msg)
+	if errors.Is(err, ErrClientClosed) || errors.Is(err, ErrClientQueueFull) {
+		d.remove(node, c)
+	}
+	return err
+}
+
+// client 获取指定节点的客户端，不存在时创建新的客户端。
+func (d *Dispatcher) client(node string) Client {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if c, ok := d.clients[node]; ok {
+		return c
+	}
+
+	c := NewClient(node, d.opts...)
+	d.clients[node] = c
+	return c
+}
+
+// remove 移除并关闭指定节点的客户端。
+func (d *Dispatcher) remove(node string, c Client) {
+	d.mu.Lock()
+	if d.clients[node] == c {
+		delete(d.clients, node)
+	}
+	d.mu.Unlock()
+
+	if err := c.Close(); err != nil {
+		d.Errorf("close node %v client err %v", node, err)
+	}
+}
+
+// Close 关闭所有节点的客户端。
+func (d *Dispatcher) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var errs []error
+	for node, c := range d.clients {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(d.clients, node)
+	}
+	return errors.Join(errs...)
+}
+
+// offlineUids 获取不在任何节点上的用户
+func offlineUids(uids []string, nodes map[string][]string) []string {
+	online := make(map[string]struct{}, len(uids))
+	for _, nodeUids := range nodes {
+		for _, uid := range nodeUids {
+			online[uid] = struct{}{}
+		}
+	}
+
+	var res []string
+	for _, uid := range uids {
+		if _, ok := online[uid]; !ok {
+			res = append(res, uid)
+		}
+	}
+	return res
+}
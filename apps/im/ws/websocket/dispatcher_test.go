@@ -0,0 +1,136 @@
+package websocket
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	locator := NewMemoryLocator()
+
+	// push 路由只向当前节点上的用户投递
+	push := func(srv *Server, conn *Conn, msg *Message) {
+		data := msg.Data.(map[string]interface{})
+		for _, uid := range data["recvIds"].([]interface{}) {
+			srv.SendByUserId(NewMessage(msg.FormId, data["content"]), uid.(string))
+		}
+	}
+
+	nodes := make(map[string]*websocket.Conn)
+	for _, uid := range []string{"u1", "u2", "u3"} {
+		srv, hs := newTestServer(t, WithServerLocator(locator, ""), WithServerInternalUsers("root"))
+		srv.AddRoutes([]Route{{Method: "push", Handler: push}})
+
+		nodes[uid] = dialTestServer(t, hs, uid, "phone", MobileDevice)
+		id := uid
+		waitFor(t, func() bool { return srv.GetConn(id) != nil })
+	}
+
+	header := http.Header{}
+	header.Set("X-User-Id", "root")
+	d := NewDispatcher(locator, WithClientHeader(header))
+	defer d.Close()
+
+	sent := make(map[string][]string)
+	err := d.Dispatch([]string{"u1", "u2", "offline"}, func(node string, uids []string) any {
+		sent[node] = uids
+		return Message{
+			FrameType: FrameData,
+			Method:    "push",
+			FormId:    "root",
+			Data: map[string]interface{}{
+				"recvIds": uids,
+				"content": "hello",
+			},
+		}
+	})
+	if err != nil {
+		t.Fatalf("Dispatch() err %v", err)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("Dispatch() nodes = %v, want 2", sent)
+	}
+
+	for _, uid := range []string{"u1", "u2"} {
+		var msg Message
+		nodes[uid].SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := nodes[uid].ReadJSON(&msg); err != nil {
+			t.Fatalf("%v read err %v", uid, err)
+		}
+		if msg.Data != "hello" {
+			t.Errorf("%v data = %v, want hello", uid, msg.Data)
+		}
+	}
+
+	// Dispatcher 的系统用户连接不记录在 Locator 中
+	if located, _ := locator.Locate("root"); len(located) != 0 {
+		t.Errorf("root located = %v, want none", located)
+	}
+
+	// u3 不在投递的用户中，不应收到消息
+	nodes["u3"].SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if _, _, err := nodes["u3"].ReadMessage(); err == nil {
+		t.Errorf("u3 should not receive message")
+	}
+}
+
+func TestDispatcher_Broadcast(t *testing.T) {
+	locator := NewMemoryLocator()
+
+	// broadcast 路由向当前节点上除投递者以外的所有连接广播，每批一个连接
+	results := make(chan BroadcastResult, 2)
+	broadcast := func(srv *Server, conn *Conn, msg *Message) {
+		results <- srv.Broadcast(NewMessage(msg.FormId, msg.Data), func(c *Conn) bool {
+			return c != conn
+		})
+	}
+
+	var conns []*websocket.Conn
+	for _, uids := range [][]string{{"u1", "u2"}, {"u3"}} {
+		srv, hs := newTestServer(t, WithServerLocator(locator, ""), WithServerBroadcastBatch(1))
+		srv.AddRoutes([]Route{{Method: "broadcast", Handler: broadcast}})
+
+		for _, uid := range uids {
+			conns = append(conns, dialTestServer(t, hs, uid, "phone", MobileDevice))
+		}
+		n := len(uids)
+		waitFor(t, func() bool { return len(srv.allConns()) == n })
+	}
+	waitFor(t, func() bool { nodes, _ := locator.Nodes(); return len(nodes) == 2 })
+
+	header := http.Header{}
+	header.Set("X-User-Id", "root")
+	d := NewDispatcher(locator, WithClientHeader(header))
+	defer d.Close()
+
+	sent, err := d.Broadcast(func(node string) any {
+		return Message{FrameType: FrameData, Method: "broadcast", FormId: "root", Data: "notice"}
+	})
+	if err != nil || sent != 2 {
+		t.Fatalf("Broadcast() = %v, %v, want 2 nodes", sent, err)
+	}
+
+	var delivered int
+	for i := 0; i < 2; i++ {
+		select {
+		case res := <-results:
+			delivered += res.Delivered
+		case <-time.After(2 * time.Second):
+			t.Fatalf("wait broadcast result timeout")
+		}
+	}
+	if delivered != 3 {
+		t.Errorf("delivered = %v, want 3", delivered)
+	}
+
+	for i, c := range conns {
+		var msg Message
+		c.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := c.ReadJSON(&msg); err != nil || msg.Data != "notice" {
+			t.Errorf("conn %v read = %+v, %v, want notice", i, msg, err)
+		}
+	}
+}
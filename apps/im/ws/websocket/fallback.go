@@ -0,0 +1,430 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// HTTP 回退的路由，相对 WithServerFallback 设置的前缀：
+//   - POST /session: 鉴权后创建会话，返回 {"sid": "..."}；鉴权及设备参数与 WebSocket 连接相同。
+//   - DELETE /session?sid=: 关闭会话。
+//   - POST /send?sid=: 发送一条消息，请求体为 JSON 编码的 Message。
+//   - GET /sse?sid=: 以 SSE 接收推送，每条消息为一个 data 事件，连接关闭时发送 close 事件。
+//   - GET /poll?sid=: 长轮询接收推送，返回消息的 JSON 数组；超时没有消息时返回 204，连接关闭时返回 410 及关闭原因。
+//
+// 会话 ID 也可以通过请求头 X-Session-Id 携带；会话不存在（已关闭或已过期）时返回 404，客户端需要重新创建会话。
+const (
+	fallbackSessionPath = "/session"
+	fallbackSendPath    = "/send"
+	fallbackSSEPath     = "/sse"
+	fallbackPollPath    = "/poll"
+
+	fallbackSessionHeader = "X-Session-Id"
+)
+
+// ErrFallbackWriteTimeout 客户端长时间没有接收推送，发送队列已满。
+var ErrFallbackWriteTimeout = errors.New("websocket fallback write timeout")
+
+// FallbackClose 会话关闭时返回给客户端的关闭码及原因，与 WebSocket 的关闭消息一致。
+type FallbackClose struct {
+	Code   int    `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// httpTransport HTTP 回退会话的传输方式。
+//
+// 客户端发送的消息放入 in，推送的消息放入 out，由 SSE 或长轮询的请求取出；
+// 没有请求接收推送超过 defaultFallbackIdle 时会话过期，连接被关闭。
+type httpTransport struct {
+	in   chan []byte
+	out  chan []byte
+	done chan struct{}
+
+	closeOnce sync.Once
+	onClose   func()
+	onIdle    func()
+
+	mu       sync.Mutex
+	deadline time.Time
+	closed   FallbackClose
+	readers  int
+	idle     *time.Timer
+}
+
+func newHTTPTransport() *httpTransport {
+	return &httpTransport{
+		in:   make(chan []byte, defaultFallbackQueueSize),
+		out:  make(chan []byte, defaultFallbackQueueSize),
+		done: make(chan struct{}),
+		closed: FallbackClose{
+			Code: websocket.CloseNormalClosure,
+		},
+	}
+}
+
+func (t *httpTransport) ReadMessage() (int, []byte, error) {
+	select {
+	case data := <-t.in:
+		return websocket.TextMessage, data, nil
+	case <-t.done:
+		return 0, nil, ErrConnClosed
+	}
+}
+
+func (t *httpTransport) WriteMessage(messageType int, data []byte) error {
+	t.mu.Lock()
+	deadline := t.deadline
+	t.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case t.out <- data:
+		return nil
+	case <-t.done:
+		return ErrConnClosed
+	case <-timeout:
+		return ErrFallbackWriteTimeout
+	}
+}
+
+// WriteControl 只处理关闭消息，记录关闭码及原因，在接收推送的请求中返回给客户端。
+func (t *httpTransport) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	if messageType != websocket.CloseMessage || len(data) < 2 {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = FallbackClose{
+		Code:   int(binary.BigEndian.Uint16(data)),
+		Reason: string(data[2:]),
+	}
+	return nil
+}
+
+func (t *httpTransport) SetWriteDeadline(deadline time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.deadline = deadline
+	return nil
+}
+
+func (t *httpTransport) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.done)
+
+		t.mu.Lock()
+		if t.idle != nil {
+			t.idle.Stop()
+		}
+		t.mu.Unlock()
+
+		if t.onClose != nil {
+			t.onClose()
+		}
+	})
+	return nil
+}
+
+// closeInfo 返回会话关闭的关闭码及原因。
+func (t *httpTransport) closeInfo() FallbackClose {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.closed
+}
+
+// attach 开始接收推送的请求，停止会话过期的计时。
+func (t *httpTransport) attach() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.readers++
+	if t.idle != nil {
+		t.idle.Stop()
+		t.idle = nil
+	}
+}
+
+// detach 结束接收推送的请求，没有请求接收推送时开始会话过期的计时。
+func (t *httpTransport) detach() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.readers--
+	t.waitIdle()
+}
+
+// waitIdle 没有请求接收推送时开始会话过期的计时，超过 defaultFallbackIdle 后关闭连接，调用方需要持有 mu。
+func (t *httpTransport) waitIdle() {
+	if t.readers > 0 || t.idle != nil {
+		return
+	}
+	select {
+	case <-t.done:
+	default:
+		t.idle = time.AfterFunc(defaultFallbackIdle, t.onIdle)
+	}
+}
+
+// deliver 将客户端发送的消息交给连接读取。
+func (t *httpTransport) deliver(ctx context.Context, data []byte) error {
+	select {
+	case t.in <- data:
+		return nil
+	case <-t.done:
+		return ErrConnClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fallbackHandler 返回 HTTP 回退的路由处理，路由为去掉前缀后的路径。
+func (s *Server) fallbackHandler() http.Handler {
+	allow := checkOrigin(s.opt.allowOrigins)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if r := recover(); r != nil {
+				s.Errorf("server handler fallback recover err %v", r)
+			}
+		}()
+
+		if !allow(r) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.URL.Path == fallbackSessionPath && r.Method == http.MethodPost:
+			s.fallbackOpen(w, r)
+		case r.URL.Path == fallbackSessionPath && r.Method == http.MethodDelete:
+			s.fallbackClose(w, r)
+		case r.URL.Path == fallbackSendPath && r.Method == http.MethodPost:
+			s.fallbackSend(w, r)
+		case r.URL.Path == fallbackSSEPath && r.Method == http.MethodGet:
+			s.fallbackSSE(w, r)
+		case r.URL.Path == fallbackPollPath && r.Method == http.MethodGet:
+			s.fallbackPoll(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// fallbackOpen 鉴权后创建会话，会话的连接与 WebSocket 连接一样记录到服务器中。
+func (s *Server) fallbackOpen(w http.ResponseWriter, r *http.Request) {
+	// 服务停止期间不再接收新的连接
+	if s.draining.Load() {
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
+	if !s.authentication.Auth(w, r) {
+		metricAuthFailures.Inc()
+		http.Error(w, "不具备访问权限", http.StatusUnauthorized)
+		return
+	}
+
+	sid, err := newSessionId()
+	if err != nil {
+		s.Errorf("new fallback session id err %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	transport := newHTTPTransport()
+	conn := newConn(s, transport, JSONCodec, r)
+	transport.onIdle = func() {
+		s.Infof("fallback session %v uid %v idle timeout", sid, conn.Uid)
+		s.Close(conn)
+	}
+	transport.onClose = func() {
+		s.sessionMu.Lock()
+		delete(s.sessions, sid)
+		s.sessionMu.Unlock()
+	}
+
+	s.sessionMu.Lock()
+	s.sessions[sid] = conn
+	s.sessionMu.Unlock()
+
+	s.serveConn(conn, r)
+	// 客户端创建会话后需要尽快开始接收推送
+	transport.mu.Lock()
+	transport.waitIdle()
+	transport.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"sid": sid})
+}
+
+// fallbackSession 获取请求对应的会话，不存在时返回 404。
+func (s *Server) fallbackSession(w http.ResponseWriter, r *http.Request) (*Conn, *httpTransport, bool) {
+	sid := r.URL.Query().Get("sid")
+	if sid == "" {
+		sid = r.Header.Get(fallbackSessionHeader)
+	}
+
+	s.sessionMu.Lock()
+	conn := s.sessions[sid]
+	s.sessionMu.Unlock()
+	if conn == nil {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return nil, nil, false
+	}
+	return conn, conn.Transport.(*httpTransport), true
+}
+
+func (s *Server) fallbackClose(w http.ResponseWriter, r *http.Request) {
+	conn, _, ok := s.fallbackSession(w, r)
+	if !ok {
+		return
+	}
+	s.Close(conn)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) fallbackSend(w http.ResponseWriter, r *http.Request) {
+	_, transport, ok := s.fallbackSession(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, defaultFallbackBodyLimit))
+	if err != nil || len(data) == 0 {
+		http.Error(w, "invalid message", http.StatusBadRequest)
+		return
+	}
+
+	if err := transport.deliver(r.Context(), data); err != nil {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fallbackSSE 以 SSE 持续推送消息，定期发送注释行避免代理断开空闲的连接。
+func (s *Server) fallbackSSE(w http.ResponseWriter, r *http.Request) {
+	_, transport, ok := s.fallbackSession(w, r)
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	transport.attach()
+	defer transport.detach()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(defaultFallbackHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case data := <-transport.out:
+			_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+		case <-heartbeat.C:
+			_, err = io.WriteString(w, ": ping\n\n")
+		case <-transport.done:
+			data, _ := json.Marshal(transport.closeInfo())
+			fmt.Fprintf(w, "event: close\ndata: %s\n\n", data)
+			flusher.Flush()
+			return
+		case <-s.streamStop:
+			return
+		case <-r.Context().Done():
+			return
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// fallbackPoll 等待推送的消息，返回当前所有待接收的消息。
+func (s *Server) fallbackPoll(w http.ResponseWriter, r *http.Request) {
+	_, transport, ok := s.fallbackSession(w, r)
+	if !ok {
+		return
+	}
+
+	transport.attach()
+	defer transport.detach()
+
+	timer := time.NewTimer(defaultFallbackPoll)
+	defer timer.Stop()
+
+	var batch [][]byte
+	select {
+	case data := <-transport.out:
+		batch = append(batch, data)
+	case <-transport.done:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusGone)
+		json.NewEncoder(w).Encode(transport.closeInfo())
+		return
+	case <-timer.C:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case <-s.streamStop:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case <-r.Context().Done():
+		return
+	}
+
+	// 一次取出所有待接收的消息
+collect:
+	for len(batch) < defaultFallbackQueueSize {
+		select {
+		case data := <-transport.out:
+			batch = append(batch, data)
+		default:
+			break collect
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("["))
+	w.Write(bytes.Join(batch, []byte(",")))
+	w.Write([]byte("]"))
+}
+
+// newSessionId 生成随机的会话 ID，会话 ID 即为会话的凭证，不能被猜测。
+func newSessionId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
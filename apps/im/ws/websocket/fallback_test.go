@@ -0,0 +1,99 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_Fallback(t *testing.T) {
+	srv := NewServer("", WithServerAuthentication(new(testAuthentication)), WithServerFallback("/ws/http"))
+	srv.AddRoutes([]Route{{Method: "echo", Handler: func(srv *Server, conn *Conn, msg *Message) {
+		srv.Send(NewResultMessage(msg, msg.Data), conn)
+	}}})
+	hs := httptest.NewServer(srv.Handler())
+	t.Cleanup(hs.Close)
+
+	base := hs.URL + "/ws/http"
+	if resp, err := http.Post(base+"/session", "application/json", nil); err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("open without auth = %v, %v, want 401", resp, err)
+	}
+
+	resp, err := http.Post(base+"/session?userId=u1&deviceId=web&deviceType=web", "application/json", nil)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("open session = %v, %v", resp, err)
+	}
+	var session struct {
+		Sid string `json:"sid"`
+	}
+	json.NewDecoder(resp.Body).Decode(&session)
+	resp.Body.Close()
+	waitFor(t, func() bool { return srv.GetDeviceConn("u1", "web") != nil })
+
+	// 通过长轮询接收请求的回复
+	body := `{"frameType":0,"id":"1","method":"echo","data":"hello"}`
+	if resp, err := http.Post(base+"/send?sid="+session.Sid, "application/json", strings.NewReader(body)); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("send = %v, %v", resp, err)
+	}
+	resp, err = http.Get(base + "/poll?sid=" + session.Sid)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("poll = %v, %v", resp, err)
+	}
+	var msgs []Message
+	json.NewDecoder(resp.Body).Decode(&msgs)
+	resp.Body.Close()
+	if len(msgs) != 1 || msgs[0].FrameType != FrameResult || msgs[0].Id != "1" || msgs[0].Data != "hello" {
+		t.Fatalf("poll = %+v, want echo result", msgs)
+	}
+
+	// 通过 SSE 接收推送，连接关闭时收到关闭事件
+	resp, err = http.Get(base + "/sse?sid=" + session.Sid)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("sse = %v, %v", resp, err)
+	}
+	defer resp.Body.Close()
+	if err := srv.SendByUserId(NewMessage("u2", "push"), "u1"); err != nil {
+		t.Fatalf("send by user id err %v", err)
+	}
+
+	lines := make(chan string, 8)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				lines <- line
+			}
+		}
+		close(lines)
+	}()
+	readLine := func() string {
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(2 * time.Second):
+			t.Fatalf("read sse timeout")
+			return ""
+		}
+	}
+
+	var msg Message
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(readLine(), "data: ")), &msg); err != nil || msg.Data != "push" {
+		t.Fatalf("sse = %+v, %v, want push", msg, err)
+	}
+
+	srv.closeWithCode(srv.GetDeviceConn("u1", "web"), CloseTokenRevoked, "token revoked")
+	if line := readLine(); line != "event: close" {
+		t.Fatalf("sse = %v, want close event", line)
+	}
+	if line := readLine(); line != `data: {"code":4003,"reason":"token revoked"}` {
+		t.Fatalf("sse = %v, want close reason", line)
+	}
+
+	if resp, err := http.Get(base + "/poll?sid=" + session.Sid); err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("poll closed session = %v, %v, want 404", resp, err)
+	}
+}
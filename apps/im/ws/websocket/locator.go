@@ -0,0 +1,273 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// Locator 记录用户连接所在的 im.ws 节点，用于多个节点之间的消息路由。
+//
+// 每个用户的每个设备对应一条记录，记录需要按设备定期刷新，节点异常退出后该设备的记录过期，
+// 即使用户的其他设备仍然在线，也不会再路由到已退出的节点。
+type Locator interface {
+	// Register 记录用户的设备连接在 node 节点上。
+	Register(uid, deviceId, node string) error
+	// Unregister 移除用户设备的连接记录，只有记录仍属于 node 节点时才会移除。
+	Unregister(uid, deviceId, node string) error
+	// Refresh 刷新用户设备连接记录的过期时间。
+	Refresh(uid, deviceId, node string) error
+	// Locate 查询用户所在的节点，返回 节点 -> 用户 ID 列表，离线的用户不会出现在结果中。
+	Locate(uids ...string) (map[string][]string, error)
+	// Alive 记录 node 节点存活，需要定期刷新，超过过期时间未刷新的节点视为下线。
+	Alive(node string) error
+	// Nodes 查询所有存活的节点，用于向所有节点广播消息。
+	Nodes() ([]string, error)
+}
+
+const (
+	locationKeyPrefix  = "ws:location:"
+	nodesKey           = "ws:nodes"
+	defaultLocationTTL = 90 // 秒
+)
+
+// registerScript 记录设备所在的节点并清理已经过期的设备，值为 {node}|{过期时间}，与 pkg/presence 的格式相同。
+var registerScript = redis.NewScript(`
+local now = tonumber(ARGV[3])
+local fields = redis.call("HGETALL", KEYS[1])
+for i = 1, #fields, 2 do
+    local expireAt = tonumber(string.match(fields[i + 1], "|(%d+)$"))
+    if expireAt == nil or expireAt < now then
+        redis.call("HDEL", KEYS[1], fields[i])
+    end
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2] .. "|" .. (now + tonumber(ARGV[4])))
+redis.call("EXPIRE", KEYS[1], ARGV[4])
+return 1
+`)
+
+// unregisterScript 仅当设备仍然在当前节点上时才删除记录，避免误删设备在其他节点上的新连接。
+var unregisterScript = redis.NewScript(`
+local v = redis.call("HGET", KEYS[1], ARGV[1])
+if v and string.find(v, ARGV[2] .. "|", 1, true) == 1 then
+    return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// redisLocator 基于 Redis 的 Locator 实现。
+//
+// 每个用户对应一个 hash，key 为 ws:location:{uid}，field 为设备 ID，value 为 {节点地址}|{过期时间}，
+// 过期的设备在查询时忽略、在下次写入时清理。
+// 存活的节点记录在 zset ws:nodes 中，score 为节点记录的过期时间。
+type redisLocator struct {
+	*redis.Redis
+	ttl int
+}
+
+// NewRedisLocator 创建一个基于 Redis 的 Locator。
+//
+// 参数:
+//   - rds: Redis 客户端。
+//   - ttl: 连接记录的过期时间（秒），小于等于 0 时使用默认值。
+//
+// 返回:
+//   - Locator: 基于 Redis 的用户节点记录。
+func NewRedisLocator(rds *redis.Redis, ttl int) Locator {
+	if ttl <= 0 {
+		ttl = defaultLocationTTL
+	}
+	return &redisLocator{
+		Redis: rds,
+		ttl:   ttl,
+	}
+}
+
+func locationKey(uid string) string {
+	return locationKeyPrefix + uid
+}
+
+func (l *redisLocator) Register(uid, deviceId, node string) error {
+	_, err := l.ScriptRun(registerScript, []string{locationKey(uid)}, deviceId, node, time.Now().Unix(), l.ttl)
+	return err
+}
+
+func (l *redisLocator) Unregister(uid, deviceId, node string) error {
+	_, err := l.ScriptRun(unregisterScript, []string{locationKey(uid)}, deviceId, node)
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
+func (l *redisLocator) Refresh(uid, deviceId, node string) error {
+	return l.Register(uid, deviceId, node)
+}
+
+func (l *redisLocator) Locate(uids ...string) (map[string][]string, error) {
+	if len(uids) == 0 {
+		return nil, nil
+	}
+
+	cmds := make([]interface {
+		Result() (map[string]string, error)
+	}, len(uids))
+	err := l.PipelinedCtx(context.Background(), func(pipe redis.Pipeliner) error {
+		for i, uid := range uids {
+			cmds[i] = pipe.HGetAll(context.Background(), locationKey(uid))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("locate users err %v", err)
+	}
+
+	now := time.Now().Unix()
+	res := make(map[string][]string)
+	for i, cmd := range cmds {
+		values, err := cmd.Result()
+		if err != nil {
+			return nil, err
+		}
+
+		// 忽略已经过期的设备，例如异常退出的节点上遗留的记录
+		devices := make(map[string]string, len(values))
+		for deviceId, value := range values {
+			if node, expireAt := parseLocation(value); expireAt >= now {
+				devices[deviceId] = node
+			}
+		}
+		appendLocation(res, uids[i], devices)
+	}
+	return res, nil
+}
+
+// parseLocation 解析设备记录的节点及过期时间，格式错误时视为已过期。
+func parseLocation(value string) (string, int64) {
+	idx := strings.LastIndexByte(value, '|')
+	if idx < 0 {
+		return value, 0
+	}
+	expireAt, err := strconv.ParseInt(value[idx+1:], 10, 64)
+	if err != nil {
+		return value[:idx], 0
+	}
+	return value[:idx], expireAt
+}
+
+func (l *redisLocator) Alive(node string) error {
+	_, err := l.Zadd(nodesKey, time.Now().Unix()+int64(l.ttl), node)
+	return err
+}
+
+func (l *redisLocator) Nodes() ([]string, error) {
+	now := time.Now().Unix()
+	if _, err := l.Zremrangebyscore(nodesKey, 0, now); err != nil {
+		return nil, err
+	}
+
+	pairs, err := l.ZrangebyscoreWithScores(nodesKey, now+1, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		nodes = append(nodes, pair.Key)
+	}
+	return nodes, nil
+}
+
+// appendLocation 将用户各个设备所在的节点合并到结果中，同一节点上的用户只记录一次。
+func appendLocation(res map[string][]string, uid string, devices map[string]string) {
+	nodes := make(map[string]struct{}, len(devices))
+	for _, node := range devices {
+		if _, ok := nodes[node]; ok {
+			continue
+		}
+		nodes[node] = struct{}{}
+		res[node] = append(res[node], uid)
+	}
+}
+
+// memoryLocator 基于内存的 Locator 实现，适用于单进程部署及测试。
+type memoryLocator struct {
+	mu        sync.RWMutex
+	locations map[string]map[string]string
+	nodes     map[string]struct{}
+}
+
+// NewMemoryLocator 创建一个基于内存的 Locator，多个 Server 共享同一个实例即可在进程内完成路由。
+func NewMemoryLocator() Locator {
+	return &memoryLocator{
+		locations: make(map[string]map[string]string),
+		nodes:     make(map[string]struct{}),
+	}
+}
+
+func (l *memoryLocator) Register(uid, deviceId, node string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	devices := l.locations[uid]
+	if devices == nil {
+		devices = make(map[string]string)
+		l.locations[uid] = devices
+	}
+	devices[deviceId] = node
+	return nil
+}
+
+func (l *memoryLocator) Unregister(uid, deviceId, node string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	devices := l.locations[uid]
+	if devices[deviceId] != node {
+		return nil
+	}
+	delete(devices, deviceId)
+	if len(devices) == 0 {
+		delete(l.locations, uid)
+	}
+	return nil
+}
+
+func (l *memoryLocator) Refresh(uid, deviceId, node string) error {
+	return nil
+}
+
+func (l *memoryLocator) Locate(uids ...string) (map[string][]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	res := make(map[string][]string)
+	for _, uid := range uids {
+		appendLocation(res, uid, l.locations[uid])
+	}
+	return res, nil
+}
+
+func (l *memoryLocator) Alive(node string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.nodes[node] = struct{}{}
+	return nil
+}
+
+func (l *memoryLocator) Nodes() ([]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	res := make([]string, 0, len(l.nodes))
+	for node := range l.nodes {
+		res = append(res, node)
+	}
+	return res, nil
+}
@@ -1,6 +1,12 @@
 package websocket
 
-import "time"
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	zrpcErr "github.com/zeromicro/x/errors"
+	"im-chat/easy-chat/pkg/xerr"
+)
 
 type FrameType uint8
 
@@ -10,14 +16,17 @@ const (
 	FramePing  FrameType = 0x1
 	FrameAck   FrameType = 0x2
 	FrameNoAck FrameType = 0x3
-	FrameErr   FrameType = 0x9
+	// FrameResult 请求处理成功的结果，Id 及 Method 与请求相同
+	FrameResult FrameType = 0x4
+	// FrameGoAway 服务即将停止，通知客户端重新连接到其他节点
+	FrameGoAway FrameType = 0x7
+	FrameErr    FrameType = 0x9
 
 	//FrameHeaders      FrameType = 0x1
 	//FramePriority     FrameType = 0x2
 	//FrameRSTStream    FrameType = 0x3
 	//FrameSettings     FrameType = 0x4
 	//FramePushPromise  FrameType = 0x5
-	//FrameGoAway       FrameType = 0x7
 	//FrameWindowUpdate FrameType = 0x8
 	//FrameContinuation FrameType = 0x9
 )
@@ -69,3 +78,62 @@ func NewErrMessage(err error) *Message {
 		Data:      err.Error(),
 	}
 }
+
+// ErrData 结构化的错误信息，作为 FrameErr 消息的内容返回给客户端。
+//
+// 字段:
+//   - Code: 错误码，定义在 xerr 中。
+//   - Msg: 错误描述。
+type ErrData struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// NewResultMessage 创建一个请求处理成功的结果消息。
+//
+// 结果消息的类型为 `FrameResult`，沿用请求的 `Id` 及 `Method`，客户端据此对应发出的请求。
+//
+// 参数:
+//   - req: 客户端发送的请求消息。
+//   - data: 处理的结果，没有结果时为 nil。
+//
+// 返回值:
+//   - *Message: 返回创建好的结果消息对象。
+func NewResultMessage(req *Message, data interface{}) *Message {
+	return &Message{
+		FrameType: FrameResult,
+		Id:        req.Id,
+		Method:    req.Method,
+		Data:      data,
+	}
+}
+
+// NewErrReply 创建一个请求处理失败的错误消息。
+//
+// 错误消息的类型为 `FrameErr`，沿用请求的 `Id` 及 `Method`，消息体为 ErrData。
+// 错误为 xerr 创建的错误时使用其错误码及描述，否则使用 xerr.SERVER_COMMON_ERROR，避免将内部错误暴露给客户端。
+//
+// 参数:
+//   - req: 客户端发送的请求消息。
+//   - err: 处理过程中发生的错误。
+//
+// 返回值:
+//   - *Message: 返回创建好的错误消息对象。
+func NewErrReply(req *Message, err error) *Message {
+	code, msg := xerr.SERVER_COMMON_ERROR, xerr.ErrMsg(xerr.SERVER_COMMON_ERROR)
+	if e, ok := errors.Cause(err).(*zrpcErr.CodeMsg); ok {
+		code, msg = e.Code, e.Msg
+	}
+
+	return &Message{
+		FrameType: FrameErr,
+		Id:        req.Id,
+		Method:    req.Method,
+		Data:      &ErrData{Code: code, Msg: msg},
+	}
+}
+
+// newCodeErrMessage 创建一个错误码对应的错误消息，回复客户端发送的消息。
+func newCodeErrMessage(req *Message, code int) *Message {
+	return NewErrReply(req, xerr.New(code, xerr.ErrMsg(code)))
+}
@@ -0,0 +1,156 @@
+package websocket
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const metricNamespace = "ws_server"
+
+// 网关的监控指标，通过 go-zero 的 metric 注册到 Prometheus，开启 Prometheus 后才会更新。
+var (
+	metricConnections = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "conn",
+		Name:      "active",
+		Help:      "websocket server active connections.",
+	})
+	metricUsers = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "user",
+		Name:      "active",
+		Help:      "websocket server online users.",
+	})
+
+	metricFramesIn = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "frame",
+		Name:      "in_total",
+		Help:      "websocket server inbound frames.",
+		Labels:    []string{"method"},
+	})
+	metricFramesOut = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "frame",
+		Name:      "out_total",
+		Help:      "websocket server outbound frames.",
+		Labels:    []string{"method"},
+	})
+
+	metricHandlerDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "handler",
+		Name:      "duration_ms",
+		Help:      "websocket server handler duration(ms).",
+		Labels:    []string{"method"},
+		Buckets:   []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500},
+	})
+	metricAckDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "ack",
+		Name:      "duration_ms",
+		Help:      "websocket server ack round-trip duration(ms).",
+		Labels:    []string{"kind"},
+		Buckets:   []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	})
+
+	metricAuthFailures = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "auth",
+		Name:      "failures_total",
+		Help:      "websocket server authentication failures.",
+	})
+	metricIdleDisconnects = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "conn",
+		Name:      "idle_disconnects_total",
+		Help:      "websocket server connections closed for idle.",
+	})
+	metricAckTimeouts = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "ack",
+		Name:      "timeouts_total",
+		Help:      "websocket server ack timeouts.",
+		Labels:    []string{"kind"},
+	})
+	metricSendErrors = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "send",
+		Name:      "errors_total",
+		Help:      "websocket server send errors.",
+		Labels:    []string{"reason"},
+	})
+)
+
+// 确认的类型：客户端消息的确认及服务端推送的确认
+const (
+	ackKindRigor = "rigor"
+	ackKindPush  = "push"
+)
+
+const unknownMethod = "unknown"
+
+// frameLabel 返回接收的消息在监控指标中的 method 标签。
+//
+// 数据消息使用路由的方法名，未注册的方法统一为 unknown，避免客户端随意发送的方法名导致标签过多；
+// 其余类型的消息使用消息类型的名称。
+func (s *Server) frameLabel(msg *Message) string {
+	if msg.FrameType != FrameData {
+		return frameName(msg.FrameType)
+	}
+	if _, ok := s.routes[msg.Method]; ok {
+		return msg.Method
+	}
+	return unknownMethod
+}
+
+// outboundLabel 返回发送的消息在监控指标中的 method 标签，服务端发送的方法名是有限的，直接使用。
+func outboundLabel(msg any) string {
+	m, ok := msg.(*Message)
+	if !ok {
+		return unknownMethod
+	}
+	if m.FrameType == FrameData && m.Method != "" {
+		return m.Method
+	}
+	return frameName(m.FrameType)
+}
+
+func frameName(t FrameType) string {
+	switch t {
+	case FrameData:
+		return "data"
+	case FramePing:
+		return "ping"
+	case FrameAck:
+		return "ack"
+	case FrameNoAck:
+		return "noack"
+	case FrameResult:
+		return "result"
+	case FrameGoAway:
+		return "goaway"
+	case FrameErr:
+		return "err"
+	default:
+		return unknownMethod
+	}
+}
+
+// sendErrorReason 返回发送失败的原因在监控指标中的标签。
+func sendErrorReason(err error) string {
+	switch {
+	case errors.Is(err, ErrConnClosed):
+		return "closed"
+	case errors.Is(err, ErrSlowConsumer):
+		return "slow_consumer"
+	default:
+		return "other"
+	}
+}
+
+func observeAck(kind string, latency time.Duration) {
+	metricAckDuration.Observe(latency.Milliseconds(), kind)
+}
@@ -0,0 +1,58 @@
+package websocket
+
+import (
+	"runtime/debug"
+	"time"
+
+	"im-chat/easy-chat/pkg/xerr"
+)
+
+// defaultSlowThreshold 处理耗时超过该时间时记录慢日志。
+const defaultSlowThreshold = 500 * time.Millisecond
+
+// RecoverMiddleware 捕获处理函数中的 panic，记录错误堆栈并向客户端返回错误消息，避免整个服务退出。
+func RecoverMiddleware(next HandlerFunc) HandlerFunc {
+	return func(srv *Server, conn *Conn, msg *Message) {
+		defer func() {
+			if r := recover(); r != nil {
+				srv.Errorf("handle method %v uid %v panic %v\n%s", msg.Method, conn.Uid, r, debug.Stack())
+				srv.Send(NewErrReply(msg, xerr.NewInternalErr()), conn)
+			}
+		}()
+
+		next(srv, conn, msg)
+	}
+}
+
+// LogMiddleware 记录每个请求的方法、用户、设备及处理耗时。
+func LogMiddleware(next HandlerFunc) HandlerFunc {
+	return func(srv *Server, conn *Conn, msg *Message) {
+		start := time.Now()
+		next(srv, conn, msg)
+		srv.Infof("handle method %v uid %v device %v mid %v duration %v",
+			msg.Method, conn.Uid, conn.DeviceId, msg.Id, time.Since(start))
+	}
+}
+
+// TimingMiddleware 返回一个记录慢请求的中间件，处理耗时超过 threshold 时记录慢日志。
+//
+// 参数:
+//   - threshold: 慢请求的阈值，小于等于 0 时使用默认值 500ms。
+//
+// 返回:
+//   - Middleware: 记录慢请求的中间件。
+func TimingMiddleware(threshold time.Duration) Middleware {
+	if threshold <= 0 {
+		threshold = defaultSlowThreshold
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(srv *Server, conn *Conn, msg *Message) {
+			start := time.Now()
+			next(srv, conn, msg)
+			if duration := time.Since(start); duration > threshold {
+				srv.Slowf("slow handle method %v uid %v mid %v duration %v", msg.Method, conn.Uid, msg.Id, duration)
+			}
+		}
+	}
+}
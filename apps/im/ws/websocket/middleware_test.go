@@ -0,0 +1,58 @@
+package websocket
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestServer_Middleware(t *testing.T) {
+	srv, hs := newTestServer(t)
+
+	var (
+		mu    sync.Mutex
+		trace []string
+	)
+	record := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(srv *Server, conn *Conn, msg *Message) {
+				mu.Lock()
+				trace = append(trace, name)
+				mu.Unlock()
+				next(srv, conn, msg)
+			}
+		}
+	}
+
+	srv.Use(RecoverMiddleware, record("global"))
+	srv.AddRoutes(WithMiddlewares([]Middleware{record("route1"), record("route2")},
+		Route{Method: "panic", Handler: func(srv *Server, conn *Conn, msg *Message) {
+			panic("boom")
+		}},
+	))
+
+	c := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	if err := c.WriteJSON(&Message{FrameType: FrameData, Method: "panic"}); err != nil {
+		t.Fatalf("write err %v", err)
+	}
+
+	// panic 被恢复，客户端收到错误消息，连接仍然可用
+	var msg Message
+	c.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := c.ReadJSON(&msg); err != nil || msg.FrameType != FrameErr {
+		t.Fatalf("read = %+v, %v, want err frame", msg, err)
+	}
+	if err := c.WriteJSON(&Message{FrameType: FramePing}); err != nil {
+		t.Fatalf("write err %v", err)
+	}
+	if err := c.ReadJSON(&msg); err != nil || msg.FrameType != FramePing {
+		t.Fatalf("read = %+v, %v, want ping", msg, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"global", "route1", "route2"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("middleware order = %v, want %v", trace, want)
+	}
+}
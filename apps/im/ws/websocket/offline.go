@@ -0,0 +1,149 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// OfflineStore 保存推送给客户端但未被确认的消息，客户端同一设备重新连接后再次推送。
+//
+// 推送时用户没有任何连接的消息保存在设备 OfflineAnyDevice 下，由用户第一个连接的设备取出。
+type OfflineStore interface {
+	// Save 保存用户设备未确认的消息。
+	Save(uid, deviceId string, msgs ...*Message) error
+	// Load 取出用户设备保存的所有消息，取出后删除。
+	Load(uid, deviceId string) ([]*Message, error)
+}
+
+// OfflineAnyDevice 用户没有任何连接时离线消息使用的设备 ID。
+const OfflineAnyDevice = "*"
+
+const (
+	offlineKeyPrefix     = "ws:offline:"
+	defaultOfflineTTL    = 7 * 24 * 3600 // 秒
+	defaultOfflineLength = 1000
+)
+
+// loadOfflineScript 原子地取出并删除离线消息，避免多个连接重复推送。
+var loadOfflineScript = redis.NewScript(`
+local msgs = redis.call("LRANGE", KEYS[1], 0, -1)
+redis.call("DEL", KEYS[1])
+return msgs
+`)
+
+// redisOfflineStore 基于 Redis 的 OfflineStore 实现。
+//
+// 每个用户设备对应一个 list，key 为 ws:offline:{uid}:{deviceId}，只保留最近的 defaultOfflineLength 条消息。
+type redisOfflineStore struct {
+	*redis.Redis
+	ttl int
+}
+
+// NewRedisOfflineStore 创建一个基于 Redis 的 OfflineStore。
+//
+// 参数:
+//   - rds: Redis 客户端。
+//   - ttl: 离线消息的过期时间（秒），小于等于 0 时使用默认值。
+//
+// 返回:
+//   - OfflineStore: 基于 Redis 的离线消息存储。
+func NewRedisOfflineStore(rds *redis.Redis, ttl int) OfflineStore {
+	if ttl <= 0 {
+		ttl = defaultOfflineTTL
+	}
+	return &redisOfflineStore{
+		Redis: rds,
+		ttl:   ttl,
+	}
+}
+
+func offlineKey(uid, deviceId string) string {
+	return offlineKeyPrefix + uid + ":" + deviceId
+}
+
+func (o *redisOfflineStore) Save(uid, deviceId string, msgs ...*Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	values := make([]any, 0, len(msgs))
+	for _, msg := range msgs {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		values = append(values, string(data))
+	}
+
+	key := offlineKey(uid, deviceId)
+	if _, err := o.Rpush(key, values...); err != nil {
+		return err
+	}
+	if err := o.Ltrim(key, -defaultOfflineLength, -1); err != nil {
+		return err
+	}
+	return o.Expire(key, o.ttl)
+}
+
+func (o *redisOfflineStore) Load(uid, deviceId string) ([]*Message, error) {
+	res, err := o.ScriptRun(loadOfflineScript, []string{offlineKey(uid, deviceId)})
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	values, ok := res.([]any)
+	if !ok {
+		return nil, fmt.Errorf("load offline messages unexpected result %T", res)
+	}
+
+	msgs := make([]*Message, 0, len(values))
+	for _, v := range values {
+		var msg Message
+		if err := json.Unmarshal([]byte(fmt.Sprint(v)), &msg); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, &msg)
+	}
+	return msgs, nil
+}
+
+// memoryOfflineStore 基于内存的 OfflineStore 实现，适用于单进程部署及测试。
+type memoryOfflineStore struct {
+	mu   sync.Mutex
+	msgs map[string][]*Message
+}
+
+// NewMemoryOfflineStore 创建一个基于内存的 OfflineStore。
+func NewMemoryOfflineStore() OfflineStore {
+	return &memoryOfflineStore{
+		msgs: make(map[string][]*Message),
+	}
+}
+
+func (o *memoryOfflineStore) Save(uid, deviceId string, msgs ...*Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key := offlineKey(uid, deviceId)
+	o.msgs[key] = append(o.msgs[key], msgs...)
+	if n := len(o.msgs[key]); n > defaultOfflineLength {
+		o.msgs[key] = o.msgs[key][n-defaultOfflineLength:]
+	}
+	return nil
+}
+
+func (o *memoryOfflineStore) Load(uid, deviceId string) ([]*Message, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key := offlineKey(uid, deviceId)
+	msgs := o.msgs[key]
+	delete(o.msgs, key)
+	return msgs, nil
+}
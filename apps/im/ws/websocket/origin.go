@@ -0,0 +1,55 @@
+package websocket
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// checkOrigin 返回校验浏览器来源的函数，allows 为空时允许所有来源。
+//
+// 参数:
+//   - allows: 允许的来源列表，格式参见 WithServerAllowOrigins。
+//
+// 返回:
+//   - func(r *http.Request) bool: 供 websocket.Upgrader 使用的校验函数。
+func checkOrigin(allows []string) func(r *http.Request) bool {
+	if len(allows) == 0 {
+		return func(r *http.Request) bool {
+			return true
+		}
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			// 非浏览器客户端
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		for _, allow := range allows {
+			if matchOrigin(u, allow) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matchOrigin 判断来源是否与允许的来源匹配。
+func matchOrigin(origin *url.URL, allow string) bool {
+	switch {
+	case allow == "*":
+		return true
+	case strings.Contains(allow, "://"):
+		return strings.EqualFold(strings.TrimSuffix(allow, "/"), origin.Scheme+"://"+origin.Host)
+	case strings.HasPrefix(allow, "*."):
+		return strings.HasSuffix(strings.ToLower(origin.Hostname()), strings.ToLower(allow[1:]))
+	default:
+		return strings.EqualFold(allow, origin.Host) || strings.EqualFold(allow, origin.Hostname())
+	}
+}
@@ -0,0 +1,126 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+var (
+	// ErrConnClosed 连接已经关闭。
+	ErrConnClosed = errors.New("websocket conn closed")
+	// ErrSlowConsumer 连接的发送队列已满，连接作为慢消费者被断开。
+	ErrSlowConsumer = errors.New("websocket conn slow consumer")
+)
+
+// OverflowPolicy 定义了连接的发送队列已满时的处理策略。
+type OverflowPolicy int
+
+const (
+	// DropOldest 丢弃队列中最早的消息，为新消息腾出空间。
+	DropOldest OverflowPolicy = iota
+	// DisconnectSlowConsumer 断开消费过慢的连接，客户端重连后可以通过离线消息补齐。
+	DisconnectSlowConsumer
+)
+
+// SendError 记录向某个连接发送消息失败的原因。
+type SendError struct {
+	Conn *Conn
+	Err  error
+}
+
+func (e *SendError) Error() string {
+	return fmt.Sprintf("send to uid %v device %v err %v", e.Conn.Uid, e.Conn.DeviceId, e.Err)
+}
+
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
+
+// SendErrors 向多个连接发送消息时，发送失败的连接及原因，一个连接的失败不影响其余连接。
+type SendErrors []*SendError
+
+func (e SendErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap 支持通过 errors.Is 判断是否包含某种错误，例如 ErrSlowConsumer。
+func (e SendErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// enqueue 将编码后的消息放入连接的发送队列，由 writeLoop 负责写入。
+//
+// 队列已满时依据服务器的 OverflowPolicy 处理：丢弃最早的消息，或断开该连接。
+// 调用者可能是 ack 调度器的协程（重发推送、确认重试），断开连接需要访问 Redis 并执行钩子，因此在新的协程中执行。
+//
+// 参数:
+//   - data: 编码后的消息。
+//
+// 返回:
+//   - error: 连接已关闭时返回 ErrConnClosed，作为慢消费者被断开时返回 ErrSlowConsumer。
+func (c *Conn) enqueue(data []byte) error {
+	select {
+	case <-c.done:
+		return ErrConnClosed
+	default:
+	}
+
+	for {
+		atomic.AddInt32(&c.unsent, 1)
+		select {
+		case c.outbound <- data:
+			return nil
+		default:
+			atomic.AddInt32(&c.unsent, -1)
+		}
+
+		if c.s.opt.overflowPolicy == DisconnectSlowConsumer {
+			if atomic.CompareAndSwapInt32(&c.slow, 0, 1) {
+				c.s.Errorf("conn uid %v device %v send queue full, disconnect slow consumer", c.Uid, c.DeviceId)
+				threading.GoSafe(func() {
+					c.s.Close(c)
+				})
+			}
+			return ErrSlowConsumer
+		}
+
+		select {
+		case <-c.outbound:
+			atomic.AddInt32(&c.unsent, -1)
+			c.s.Infof("conn uid %v device %v send queue full, drop oldest message", c.Uid, c.DeviceId)
+		default:
+		}
+	}
+}
+
+// writeLoop 依次写入发送队列中的消息，写入失败或超时时关闭连接。
+func (c *Conn) writeLoop() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case data := <-c.outbound:
+			c.SetWriteDeadline(time.Now().Add(c.s.opt.writeTimeout))
+			err := c.WriteMessage(c.codec.MessageType(), data)
+			atomic.AddInt32(&c.unsent, -1)
+			if err != nil {
+				c.s.Errorf("conn uid %v device %v write err %v", c.Uid, c.DeviceId, err)
+				c.s.Close(c)
+				return
+			}
+		}
+	}
+}
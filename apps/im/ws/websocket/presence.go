@@ -0,0 +1,39 @@
+package websocket
+
+// Presence 维护用户的在线状态，例如 pkg/presence。
+//
+// 连接建立及保活时调用 Online 刷新设备的在线记录，连接关闭或被踢下线时调用 Offline；
+// 记录需要带有过期时间，节点异常退出后不再刷新，过期后视为离线。
+type Presence interface {
+	// Online 记录用户的设备在 node 节点上在线。
+	Online(uid, deviceId, node string) error
+	// Offline 记录用户的设备离线，只有记录仍属于 node 节点时才会移除。
+	Offline(uid, deviceId, node string) error
+}
+
+// presenceOnline 记录连接在线，未设置 Presence 或内部服务的连接不做处理。
+func (s *Server) presenceOnline(conn *Conn) {
+	if s.opt.presence == nil || conn.Internal {
+		return
+	}
+	if err := s.opt.presence.Online(conn.Uid, conn.DeviceId, s.opt.node); err != nil {
+		s.Errorf("presence online uid %v device %v err %v", conn.Uid, conn.DeviceId, err)
+	}
+}
+
+// presenceOffline 记录连接离线，未设置 Presence 或内部服务的连接不做处理。
+func (s *Server) presenceOffline(conn *Conn) {
+	if s.opt.presence == nil || conn.Internal {
+		return
+	}
+	if err := s.opt.presence.Offline(conn.Uid, conn.DeviceId, s.opt.node); err != nil {
+		s.Errorf("presence offline uid %v device %v err %v", conn.Uid, conn.DeviceId, err)
+	}
+}
+
+// runHooks 依次执行连接建立或关闭的回调。
+func (s *Server) runHooks(hooks []ConnHookFunc, conn *Conn) {
+	for _, hook := range hooks {
+		hook(s, conn)
+	}
+}
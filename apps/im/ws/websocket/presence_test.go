@@ -0,0 +1,92 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testPresence 记录每个用户在线的设备
+type testPresence struct {
+	mu      sync.Mutex
+	devices map[string]map[string]string
+	onlines int
+}
+
+func (p *testPresence) Online(uid, deviceId, node string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.devices[uid] == nil {
+		p.devices[uid] = make(map[string]string)
+	}
+	p.devices[uid][deviceId] = node
+	p.onlines++
+	return nil
+}
+
+func (p *testPresence) Offline(uid, deviceId, node string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.devices[uid][deviceId] == node {
+		delete(p.devices[uid], deviceId)
+	}
+	return nil
+}
+
+func (p *testPresence) state(uid string) (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.devices[uid]), p.onlines
+}
+
+func TestServer_Presence(t *testing.T) {
+	presence := &testPresence{devices: make(map[string]map[string]string)}
+
+	// 回调需要在服务启动之前添加
+	var srv *Server
+	hs := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.ServerWs(w, r)
+	}))
+	srv = NewServer(hs.Listener.Addr().String(),
+		WithServerAuthentication(new(testAuthentication)),
+		WithServerPresence(presence),
+		WithServerLocatorRefresh(50*time.Millisecond),
+	)
+	var connects, closes atomic.Int32
+	srv.OnConnect(func(srv *Server, conn *Conn) { connects.Add(1) })
+	srv.OnClose(func(srv *Server, conn *Conn) { closes.Add(1) })
+	hs.Start()
+	t.Cleanup(hs.Close)
+
+	phone := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	dialTestServer(t, hs, "u1", "pc", DesktopDevice)
+	waitFor(t, func() bool { devices, _ := presence.state("u1"); return devices == 2 })
+
+	// 保活时刷新在线记录
+	_, onlines := presence.state("u1")
+	waitFor(t, func() bool { _, n := presence.state("u1"); return n > onlines })
+
+	// 同类型的设备被踢下线后记录离线
+	dialTestServer(t, hs, "u1", "pad", MobileDevice)
+	waitFor(t, func() bool {
+		presence.mu.Lock()
+		defer presence.mu.Unlock()
+		_, ok := presence.devices["u1"]["phone"]
+		return !ok && len(presence.devices["u1"]) == 2
+	})
+
+	// 连接断开后记录离线
+	phone.Close()
+	pad := dialTestServer(t, hs, "u2", "pad", MobileDevice)
+	waitFor(t, func() bool { devices, _ := presence.state("u2"); return devices == 1 })
+	pad.Close()
+	waitFor(t, func() bool { devices, _ := presence.state("u2"); return devices == 0 })
+
+	waitFor(t, func() bool { return connects.Load() == 4 && closes.Load() == 2 })
+}
@@ -0,0 +1,218 @@
+package websocket
+
+import (
+	"time"
+
+	"github.com/zeromicro/go-zero/core/utils"
+)
+
+// pendingPush 等待客户端确认的推送消息。
+//
+// 字段:
+//   - msg: 推送的消息，未确认时保存到离线消息中。
+//   - data: 编码后的消息，重发时直接使用。
+//   - retries: 已经重发的次数。
+//   - sentAt: 第一次发送的时间，用于统计确认耗时。
+//   - item: 等待确认的定时任务，超时后重发。
+type pendingPush struct {
+	msg     *Message
+	data    []byte
+	retries int
+	sentAt  time.Time
+	item    *ackItem
+}
+
+// SendWithAck 向指定的连接推送需要确认的消息。
+//
+// 消息没有 Id 时会自动生成，客户端收到后需要回复相同 Id 的 FrameAck 消息。
+// 超过 WithServerPushAck 设置的时间未确认时重发，重发次数用完或连接关闭后仍未确认的消息会保存到离线消息中，
+// 同一设备重新连接后再次推送。
+// 只有握手时声明 pushAck 的连接（Conn.PushAck）才会跟踪确认，其余连接与 Send 相同，只发送一次。
+//
+// 参数:
+//   - msg: 要推送的消息。
+//   - conns: 要推送消息的连接列表。
+//
+// 返回:
+//   - error: 推送失败的连接及原因，以 SendErrors 返回；如果成功，则返回 nil。
+func (s *Server) SendWithAck(msg *Message, conns ...*Conn) error {
+	if len(conns) == 0 {
+		return nil
+	}
+	if msg.Id == "" {
+		msg.Id = utils.NewUuid()
+	}
+
+	encoded := make(map[Codec][]byte, 1)
+	label := outboundLabel(msg)
+	var errs SendErrors
+	for _, conn := range conns {
+		data, ok := encoded[conn.codec]
+		if !ok {
+			var err error
+			if data, err = conn.codec.Marshal(msg); err != nil {
+				return err
+			}
+			encoded[conn.codec] = data
+		}
+
+		send := conn.enqueue
+		if conn.PushAck {
+			send = func(data []byte) error { return conn.trackPush(msg, data) }
+		}
+		if err := send(data); err != nil {
+			metricSendErrors.Inc(sendErrorReason(err))
+			errs = append(errs, &SendError{Conn: conn, Err: err})
+			continue
+		}
+		metricFramesOut.Inc(label)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// trackPush 记录并发送需要确认的消息，连接已关闭时直接保存到离线消息中。
+func (c *Conn) trackPush(msg *Message, data []byte) error {
+	c.pushMu.Lock()
+	if c.pushes == nil {
+		c.pushMu.Unlock()
+		c.s.saveOffline(c, msg)
+		return ErrConnClosed
+	}
+
+	p := &pendingPush{msg: msg, data: data, sentAt: time.Now()}
+	p.item = c.s.scheduler.schedule(c.s.opt.pushAckTimeout, func() {
+		c.retransmitPush(msg.Id)
+	})
+	c.pushes[msg.Id] = p
+	c.pushMu.Unlock()
+
+	// 发送失败时消息仍在等待确认，连接关闭时统一保存到离线消息中
+	return c.enqueue(data)
+}
+
+// retransmitPush 重发超时未确认的消息，重发次数用完后保存到离线消息中。
+//
+// 在调度器的协程中执行。
+func (c *Conn) retransmitPush(id string) {
+	c.pushMu.Lock()
+	p, ok := c.pushes[id]
+	if !ok {
+		c.pushMu.Unlock()
+		return
+	}
+
+	if p.retries >= c.s.opt.pushRetries {
+		delete(c.pushes, id)
+		c.pushMu.Unlock()
+
+		c.s.ackStats.expired.Add(1)
+		metricAckTimeouts.Inc(ackKindPush)
+		c.s.Infof("push ack timeout uid %v device %v mid %v", c.Uid, c.DeviceId, id)
+		// 在调度器的协程中执行，不能阻塞
+		go c.s.saveOffline(c, p.msg)
+		return
+	}
+
+	p.retries++
+	p.item = c.s.scheduler.schedule(c.s.opt.pushAckTimeout, func() {
+		c.retransmitPush(id)
+	})
+	c.pushMu.Unlock()
+
+	c.s.ackStats.retries.Add(1)
+	if err := c.enqueue(p.data); err != nil {
+		c.s.Errorf("push retransmit uid %v device %v mid %v err %v", c.Uid, c.DeviceId, id, err)
+	}
+}
+
+// ackPush 客户端确认推送的消息。
+//
+// 返回:
+//   - bool: 是否为等待确认的推送消息，不是时按照客户端发送的消息处理。
+func (c *Conn) ackPush(id string) bool {
+	c.pushMu.Lock()
+	defer c.pushMu.Unlock()
+
+	p, ok := c.pushes[id]
+	if !ok {
+		return false
+	}
+	c.s.scheduler.cancel(p.item)
+	delete(c.pushes, id)
+	latency := time.Since(p.sentAt)
+	c.s.ackStats.observe(latency)
+	observeAck(ackKindPush, latency)
+	return true
+}
+
+// releasePushes 连接关闭后将所有未确认的消息保存到离线消息中，之后的推送直接保存到离线消息中。
+func (s *Server) releasePushes(conn *Conn) {
+	conn.pushMu.Lock()
+	pushes := conn.pushes
+	conn.pushes = nil
+	conn.pushMu.Unlock()
+
+	msgs := make([]*Message, 0, len(pushes))
+	for _, p := range pushes {
+		s.scheduler.cancel(p.item)
+		msgs = append(msgs, p.msg)
+	}
+	s.saveOffline(conn, msgs...)
+}
+
+// SaveOffline 保存推送给没有任何连接的用户的消息，用户任意一个设备连接后推送给该设备。
+//
+// 未配置 OfflineStore 时丢弃。
+func (s *Server) SaveOffline(uid string, msgs ...*Message) {
+	if len(msgs) == 0 || s.opt.offlineStore == nil {
+		return
+	}
+	for _, msg := range msgs {
+		if msg.Id == "" {
+			msg.Id = utils.NewUuid()
+		}
+	}
+	if err := s.opt.offlineStore.Save(uid, OfflineAnyDevice, msgs...); err != nil {
+		s.Errorf("save offline messages uid %v err %v", uid, err)
+	}
+}
+
+// saveOffline 将消息保存到离线消息中，未配置 OfflineStore 时丢弃。
+func (s *Server) saveOffline(conn *Conn, msgs ...*Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	if s.opt.offlineStore == nil {
+		s.Infof("drop %v unacked messages uid %v device %v", len(msgs), conn.Uid, conn.DeviceId)
+		return
+	}
+	if err := s.opt.offlineStore.Save(conn.Uid, conn.DeviceId, msgs...); err != nil {
+		s.Errorf("save offline messages uid %v device %v err %v", conn.Uid, conn.DeviceId, err)
+	}
+}
+
+// deliverOffline 连接建立后推送用户离线期间的消息及该设备未确认的消息。
+func (s *Server) deliverOffline(conn *Conn) {
+	if s.opt.offlineStore == nil || conn.Internal {
+		return
+	}
+
+	var msgs []*Message
+	for _, deviceId := range []string{OfflineAnyDevice, conn.DeviceId} {
+		loaded, err := s.opt.offlineStore.Load(conn.Uid, deviceId)
+		if err != nil {
+			s.Errorf("load offline messages uid %v device %v err %v", conn.Uid, deviceId, err)
+			continue
+		}
+		msgs = append(msgs, loaded...)
+	}
+	for _, msg := range msgs {
+		if err := s.SendWithAck(msg, conn); err != nil {
+			s.Errorf("deliver offline message uid %v device %v err %v", conn.Uid, conn.DeviceId, err)
+		}
+	}
+}
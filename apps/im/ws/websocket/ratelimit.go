@@ -0,0 +1,224 @@
+package websocket
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/limit"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"golang.org/x/time/rate"
+	"im-chat/easy-chat/pkg/xerr"
+)
+
+// LimitRule 令牌桶的限流规则，Rate 为 0 时不限制。
+type LimitRule struct {
+	// 每秒产生的令牌数
+	Rate int `json:",optional"`
+	// 令牌桶的容量，即允许瞬间爆发的请求数，默认等于 Rate
+	Burst int `json:",optional"`
+}
+
+// RateLimitConf 网关的限流配置。
+type RateLimitConf struct {
+	// 每个用户所有方法合计的限制
+	User LimitRule `json:",optional"`
+	// 每个连接所有方法合计的限制
+	Conn LimitRule `json:",optional"`
+	// 每个用户按方法的限制，key 为方法名，例如 conversation.chat
+	Methods map[string]LimitRule `json:",optional"`
+	// 在 ViolationWindow 秒内超过限制的次数达到 MaxViolations 时断开连接，并在 BanSeconds 秒内拒绝该用户的消息
+	MaxViolations   int `json:",default=10"`
+	ViolationWindow int `json:",default=10"`
+	BanSeconds      int `json:",default=60"`
+}
+
+const (
+	rateLimitKeyPrefix = "ws:limit:"
+	rateBanKeyPrefix   = "ws:ban:"
+
+	// 限流器的缓存时间，超过该时间未使用的限流器会被清理
+	limiterExpire = time.Minute
+	// 在 Redis 中检查用户是否被禁止的结果的缓存时间，避免每条消息都访问 Redis
+	banCheckExpire = 5 * time.Second
+)
+
+// RateLimiter 基于令牌桶的网关限流器。
+//
+// 每个节点先使用本地的令牌桶限流，配置了 Redis 时再使用 limit.TokenLimiter 在集群范围内限流，
+// 用户的连接分布在多个节点上时同样受到限制。超过限制的消息会收到错误码为 xerr.RATE_LIMIT_ERROR 的错误消息，
+// 多次超过限制的用户会被断开连接并暂时禁止。内部服务的连接（见 WithServerInternalUsers）不受限制。
+type RateLimiter struct {
+	c       RateLimitConf
+	rds     *redis.Redis
+	enabled bool
+
+	local      *collection.Cache
+	cluster    *collection.Cache
+	violations *collection.Cache
+	bans       *collection.Cache
+	banChecks  *collection.Cache
+}
+
+// NewRateLimiter 创建一个新的 RateLimiter。
+//
+// 参数:
+//   - c: 限流配置。
+//   - rds: Redis 客户端，为 nil 时只在本地限流。
+//
+// 返回:
+//   - *RateLimiter: 新创建的限流器。
+func NewRateLimiter(c RateLimitConf, rds *redis.Redis) *RateLimiter {
+	if c.ViolationWindow <= 0 {
+		c.ViolationWindow = 10
+	}
+	if c.BanSeconds <= 0 {
+		c.BanSeconds = 60
+	}
+
+	enabled := c.User.Rate > 0 || c.Conn.Rate > 0
+	for _, rule := range c.Methods {
+		enabled = enabled || rule.Rate > 0
+	}
+
+	return &RateLimiter{
+		c:          c,
+		rds:        rds,
+		enabled:    enabled,
+		local:      mustNewCache(limiterExpire),
+		cluster:    mustNewCache(limiterExpire),
+		violations: mustNewCache(time.Duration(c.ViolationWindow) * time.Second),
+		bans:       mustNewCache(time.Duration(c.BanSeconds) * time.Second),
+		banChecks:  mustNewCache(banCheckExpire),
+	}
+}
+
+func mustNewCache(expire time.Duration) *collection.Cache {
+	cache, err := collection.NewCache(expire)
+	if err != nil {
+		panic(err)
+	}
+	return cache
+}
+
+// Middleware 限流中间件。
+func (l *RateLimiter) Middleware(next HandlerFunc) HandlerFunc {
+	if !l.enabled {
+		return next
+	}
+
+	return func(srv *Server, conn *Conn, msg *Message) {
+		// 内部服务转发消息的连接，限制或禁止后集群内的推送都会中断
+		if conn.Internal {
+			next(srv, conn, msg)
+			return
+		}
+
+		if l.banned(conn.Uid) {
+			srv.Send(newCodeErrMessage(msg, xerr.RATE_LIMIT_ERROR), conn)
+			srv.closeWithCode(conn, websocket.ClosePolicyViolation, "rate limited")
+			return
+		}
+
+		if l.allow(conn, msg.Method) {
+			next(srv, conn, msg)
+			return
+		}
+
+		srv.Send(newCodeErrMessage(msg, xerr.RATE_LIMIT_ERROR), conn)
+		if l.violate(conn.Uid) {
+			srv.Infof("rate limit ban uid %v for %vs", conn.Uid, l.c.BanSeconds)
+			l.ban(conn.Uid)
+			srv.closeWithCode(conn, websocket.ClosePolicyViolation, "rate limited")
+		}
+	}
+}
+
+// allow 依次检查连接、用户及方法的限制。
+func (l *RateLimiter) allow(conn *Conn, method string) bool {
+	if !l.allowLocal("conn:"+conn.Uid+":"+conn.DeviceId, l.c.Conn) {
+		return false
+	}
+	if !l.allowKey("user:"+conn.Uid, l.c.User) {
+		return false
+	}
+	return l.allowKey("method:"+conn.Uid+":"+method, l.c.Methods[method])
+}
+
+// allowKey 先在本地限流，再在集群范围内限流。
+func (l *RateLimiter) allowKey(key string, rule LimitRule) bool {
+	if !l.allowLocal(key, rule) {
+		return false
+	}
+	if l.rds == nil || rule.Rate <= 0 {
+		return true
+	}
+
+	v, _ := l.cluster.Take(key, func() (any, error) {
+		return limit.NewTokenLimiter(rule.Rate, rule.burst(), l.rds, rateLimitKeyPrefix+key), nil
+	})
+	return v.(*limit.TokenLimiter).Allow()
+}
+
+func (l *RateLimiter) allowLocal(key string, rule LimitRule) bool {
+	if rule.Rate <= 0 {
+		return true
+	}
+
+	v, _ := l.local.Take(key, func() (any, error) {
+		return rate.NewLimiter(rate.Limit(rule.Rate), rule.burst()), nil
+	})
+	return v.(*rate.Limiter).Allow()
+}
+
+func (r LimitRule) burst() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return r.Rate
+}
+
+// violate 记录一次超过限制，返回是否需要禁止该用户。
+func (l *RateLimiter) violate(uid string) bool {
+	if l.c.MaxViolations <= 0 {
+		return false
+	}
+
+	v, _ := l.violations.Take(uid, func() (any, error) {
+		return new(atomic.Int32), nil
+	})
+	return v.(*atomic.Int32).Add(1) >= int32(l.c.MaxViolations)
+}
+
+func (l *RateLimiter) ban(uid string) {
+	l.violations.Del(uid)
+	l.bans.Set(uid, struct{}{})
+	if l.rds == nil {
+		return
+	}
+	if err := l.rds.Setex(rateBanKeyPrefix+uid, "1", l.c.BanSeconds); err != nil {
+		logx.Errorf("rate limit ban uid %v err %v", uid, err)
+	}
+}
+
+func (l *RateLimiter) banned(uid string) bool {
+	if _, ok := l.bans.Get(uid); ok {
+		return true
+	}
+	if l.rds == nil || l.c.MaxViolations <= 0 {
+		return false
+	}
+
+	// 其他节点禁止的用户最多延迟 banCheckExpire 生效
+	v, _ := l.banChecks.Take(uid, func() (any, error) {
+		ok, err := l.rds.Exists(rateBanKeyPrefix + uid)
+		if err != nil {
+			logx.Errorf("rate limit check ban uid %v err %v", uid, err)
+			return false, nil
+		}
+		return ok, nil
+	})
+	return v.(bool)
+}
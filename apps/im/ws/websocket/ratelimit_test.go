@@ -0,0 +1,90 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"im-chat/easy-chat/pkg/xerr"
+)
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	srv, hs := newTestServer(t)
+
+	limiter := NewRateLimiter(RateLimitConf{
+		Methods:       map[string]LimitRule{"echo": {Rate: 1, Burst: 1}},
+		MaxViolations: 2,
+		BanSeconds:    60,
+	}, nil)
+	srv.Use(limiter.Middleware)
+	srv.AddRoutes([]Route{{Method: "echo", Handler: func(srv *Server, conn *Conn, msg *Message) {
+		srv.Send(NewMessage(conn.Uid, msg.Data), conn)
+	}}})
+
+	c := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	c.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	if err := c.WriteJSON(&Message{FrameType: FrameData, Id: "1", Method: "echo", Data: "hi"}); err != nil {
+		t.Fatalf("write err %v", err)
+	}
+	var msg Message
+	if err := c.ReadJSON(&msg); err != nil || msg.FrameType != FrameData {
+		t.Fatalf("read = %+v, %v, want data frame", msg, err)
+	}
+
+	// 超过限制返回错误码
+	if err := c.WriteJSON(&Message{FrameType: FrameData, Id: "2", Method: "echo", Data: "hi"}); err != nil {
+		t.Fatalf("write err %v", err)
+	}
+	var errMsg struct {
+		FrameType `json:"frameType"`
+		Id        string  `json:"id"`
+		Data      ErrData `json:"data"`
+	}
+	if err := c.ReadJSON(&errMsg); err != nil || errMsg.FrameType != FrameErr || errMsg.Id != "2" ||
+		errMsg.Data.Code != xerr.RATE_LIMIT_ERROR {
+		t.Fatalf("read = %+v, %v, want rate limit err", errMsg, err)
+	}
+
+	// 多次超过限制后断开连接
+	if err := c.WriteJSON(&Message{FrameType: FrameData, Id: "3", Method: "echo", Data: "hi"}); err != nil {
+		t.Fatalf("write err %v", err)
+	}
+	for {
+		if _, _, err := c.ReadMessage(); err != nil {
+			break
+		}
+	}
+	waitFor(t, func() bool { return len(srv.GetConns("u1")) == 0 })
+	if !limiter.banned("u1") {
+		t.Errorf("u1 not banned")
+	}
+}
+
+func TestRateLimiter_Internal(t *testing.T) {
+	srv, hs := newTestServer(t, WithServerInternalUsers("root"))
+
+	limiter := NewRateLimiter(RateLimitConf{
+		Methods:       map[string]LimitRule{"echo": {Rate: 1, Burst: 1}},
+		MaxViolations: 1,
+	}, nil)
+	srv.Use(limiter.Middleware)
+	srv.AddRoutes([]Route{{Method: "echo", Handler: func(srv *Server, conn *Conn, msg *Message) {
+		srv.Send(NewMessage(conn.Uid, msg.Data), conn)
+	}}})
+
+	// 内部服务的连接不受限制，也不会被禁止
+	c := dialTestServer(t, hs, "root", "dispatcher", WebDevice)
+	c.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for i := 0; i < 3; i++ {
+		if err := c.WriteJSON(&Message{FrameType: FrameData, Method: "echo", Data: "hi"}); err != nil {
+			t.Fatalf("write err %v", err)
+		}
+		var msg Message
+		if err := c.ReadJSON(&msg); err != nil || msg.FrameType != FrameData {
+			t.Fatalf("read = %+v, %v, want data frame", msg, err)
+		}
+	}
+	if limiter.banned("root") {
+		t.Errorf("root banned")
+	}
+}
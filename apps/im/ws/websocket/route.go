@@ -1,5 +1,10 @@
 package websocket
 
+import (
+	"sync/atomic"
+	"time"
+)
+
 // Route 表示一个路由条目，用于将特定的请求方法映射到对应的处理函数。
 //
 // 字段:
@@ -20,3 +25,106 @@ type Route struct {
 //   - msg: *Message
 //     表示收到的消息，包含消息的详细信息，包括消息的类型、内容等。
 type HandlerFunc func(srv *Server, conn *Conn, msg *Message)
+
+// ConnHookFunc 定义了用户连接建立或关闭时执行的回调。
+//
+// 回调在建立或关闭连接的协程中同步执行，不能阻塞；同一设备重复连接替换旧连接时不会执行。
+type ConnHookFunc func(srv *Server, conn *Conn)
+
+// Middleware 定义了 WebSocket 路由的中间件，用于处理恢复、日志、鉴权、限流等通用逻辑。
+//
+// 中间件接收下一个处理函数并返回新的处理函数，可以在调用 next 之前或之后执行逻辑，也可以不调用 next 直接返回。
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// WithMiddlewares 为一组路由添加中间件，先添加的中间件在外层先执行。
+//
+// 参数:
+//   - ms: 中间件列表。
+//   - rs: 需要添加中间件的路由。
+//
+// 返回:
+//   - []Route: 添加中间件后的路由。
+func WithMiddlewares(ms []Middleware, rs ...Route) []Route {
+	res := make([]Route, 0, len(rs))
+	for _, r := range rs {
+		res = append(res, Route{
+			Method:  r.Method,
+			Handler: chain(ms, r.Handler),
+		})
+	}
+	return res
+}
+
+// WithMiddleware 为一组路由添加单个中间件。
+func WithMiddleware(m Middleware, rs ...Route) []Route {
+	return WithMiddlewares([]Middleware{m}, rs...)
+}
+
+// chain 将中间件依次包装到处理函数上。
+func chain(ms []Middleware, handler HandlerFunc) HandlerFunc {
+	for i := len(ms) - 1; i >= 0; i-- {
+		handler = ms[i](handler)
+	}
+	return handler
+}
+
+// RouteStats 路由的调用统计。
+type RouteStats struct {
+	// Calls 调用的次数
+	Calls int64 `json:"calls"`
+	// Errors 返回错误（FrameErr）的次数
+	Errors int64 `json:"errors"`
+	// AvgMs 平均处理耗时（毫秒）
+	AvgMs float64 `json:"avgMs"`
+	// MaxMs 最大处理耗时（毫秒）
+	MaxMs int64 `json:"maxMs"`
+}
+
+type routeStats struct {
+	calls    atomic.Int64
+	errors   atomic.Int64
+	duration atomic.Int64
+	max      atomic.Int64
+}
+
+func (r *routeStats) observe(d time.Duration) {
+	r.calls.Add(1)
+	r.duration.Add(int64(d))
+	for {
+		max := r.max.Load()
+		if int64(d) <= max || r.max.CompareAndSwap(max, int64(d)) {
+			return
+		}
+	}
+}
+
+// routeStat 获取方法的调用统计，未注册的方法统一记录为 unknown。
+func (s *Server) routeStat(method string) *routeStats {
+	if _, ok := s.routes[method]; !ok {
+		method = unknownMethod
+	}
+	if stat, ok := s.routeStats.Load(method); ok {
+		return stat.(*routeStats)
+	}
+	stat, _ := s.routeStats.LoadOrStore(method, new(routeStats))
+	return stat.(*routeStats)
+}
+
+// RouteStats 返回各个路由的调用统计，key 为方法名，未注册的方法记录为 unknown。
+func (s *Server) RouteStats() map[string]RouteStats {
+	res := make(map[string]RouteStats)
+	s.routeStats.Range(func(key, value any) bool {
+		stat := value.(*routeStats)
+		stats := RouteStats{
+			Calls:  stat.calls.Load(),
+			Errors: stat.errors.Load(),
+			MaxMs:  time.Duration(stat.max.Load()).Milliseconds(),
+		}
+		if stats.Calls > 0 {
+			stats.AvgMs = float64(stat.duration.Load()) / float64(stats.Calls) / float64(time.Millisecond)
+		}
+		res[key.(string)] = stats
+		return true
+	})
+	return res
+}
@@ -2,16 +2,18 @@ package websocket
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/zeromicro/go-zero/core/threading"
 	"time"
 
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gorilla/websocket"
 	"github.com/zeromicro/go-zero/core/logx"
+	"im-chat/easy-chat/pkg/xerr"
 )
 
 type AckType int
@@ -38,6 +40,10 @@ func (t AckType) ToString() string {
 // 字段:
 //   - routes: map[string]HandlerFunc
 //     存储与请求方法对应的处理函数的路由表，每个请求方法都映射到一个特定的 `HandlerFunc`。
+//   - middlewares: []Middleware
+//     全局中间件，作用于所有的路由。
+//   - onConnect、onClose: []ConnHookFunc
+//     用户连接建立及关闭后执行的回调。
 //   - addr: string
 //     服务器监听的地址，表示 WebSocket 服务器将在哪个地址和端口上监听连接。
 //   - patten: string
@@ -50,14 +56,24 @@ func (t AckType) ToString() string {
 //     日志记录器，用于记录服务器的日志信息，包括错误、信息和调试日志。
 //   - connToUser: map[*Conn]string
 //     连接到用户映射表，将每个 WebSocket 连接映射到其对应的用户 ID。
-//   - userToConn: map[string]*Conn
-//     用户到连接映射表，将每个用户 ID 映射到其当前的 WebSocket 连接。
+//   - userToConn: map[string]map[string]*Conn
+//     用户到连接映射表，按 用户 ID -> 设备 ID -> 连接 记录用户在各个设备上的 WebSocket 连接。
 //   - TaskRunner: *threading.TaskRunner
 //     任务运行器，用于管理和执行异步任务。
 //   - RWMutex: sync.RWMutex
 //     读写互斥锁，用于保护连接和用户映射表的并发读写操作。
 //   - authentication: Authentication
 //     鉴权接口，负责处理 WebSocket 连接的鉴权逻辑。
+//   - mux、httpServer: *http.ServeMux、*http.Server
+//     服务器独立使用的路由及 HTTP 服务，用于停止服务时关闭监听。
+//   - draining: atomic.Bool
+//     服务是否正在停止，停止期间不再接收新的连接。
+//   - scheduler: *ackScheduler
+//     所有连接共享的确认超时调度器。
+//   - sessions: map[string]*Conn
+//     HTTP 回退的会话 ID 到连接的映射。
+//   - streamStop: chan struct{}
+//     停止服务时结束 HTTP 回退中正在等待推送的请求。
 type Server struct {
 	sync.RWMutex
 
@@ -66,15 +82,33 @@ type Server struct {
 	opt            *serverOption
 	authentication Authentication
 
-	routes map[string]HandlerFunc
-	addr   string
-	patten string
+	routes      map[string]HandlerFunc
+	middlewares []Middleware
+	onConnect   []ConnHookFunc
+	onClose     []ConnHookFunc
+	addr        string
+	patten      string
 
 	connToUser map[*Conn]string
-	userToConn map[string]*Conn
+	userToConn map[string]map[string]*Conn
 
 	upgrader websocket.Upgrader
 	logx.Logger
+
+	mux        *http.ServeMux
+	muxOnce    sync.Once
+	httpServer *http.Server
+	draining   atomic.Bool
+	stopOnce   sync.Once
+	stopped    chan struct{}
+
+	scheduler  *ackScheduler
+	ackStats   ackStats
+	routeStats sync.Map
+
+	sessionMu  sync.Mutex
+	sessions   map[string]*Conn
+	streamStop chan struct{}
 }
 
 // NewServer 创建一个新的服务器实例
@@ -91,26 +125,49 @@ type Server struct {
 //   - *Server: 初始化后的 `Server` 实例。
 func NewServer(addr string, opts ...ServerOptions) *Server {
 	opt := newServerOptions(opts...)
+	if opt.node == "" {
+		opt.node = addr
+	}
 
-	return &Server{
+	mux := http.NewServeMux()
+	s := &Server{
 		routes: make(map[string]HandlerFunc),
 		addr:   addr,
 		patten: opt.patten,
 		opt:    &opt,
 		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
+			CheckOrigin: checkOrigin(opt.allowOrigins),
 		},
 
 		authentication: opt.Authentication,
 
 		connToUser: make(map[*Conn]string),
-		userToConn: make(map[string]*Conn),
+		userToConn: make(map[string]map[string]*Conn),
 
 		Logger:     logx.WithContext(context.Background()),
 		TaskRunner: threading.NewTaskRunner(opt.concurrency),
+
+		mux: mux,
+		httpServer: &http.Server{
+			Addr:    addr,
+			Handler: mux,
+		},
+		stopped:   make(chan struct{}),
+		scheduler: newAckScheduler(),
+
+		sessions:   make(map[string]*Conn),
+		streamStop: make(chan struct{}),
+	}
+
+	if opt.locator != nil {
+		go s.alive()
+	}
+
+	// 鉴权支持令牌过期时，客户端可以在连接内重新认证
+	if _, ok := s.tokenAuth(); ok {
+		s.routes[AuthRefreshMethod] = s.authRefresh
 	}
+	return s
 }
 
 // ServerWs 处理 WebSocket 连接请求。
@@ -128,8 +185,14 @@ func (s *Server) ServerWs(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	// 服务停止期间不再接收新的连接
+	if s.draining.Load() {
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
 	conn := NewConn(s, w, r)
-	if conn != nil { // todo 这里为什么是等于，感觉是不等于
+	if conn == nil {
 		return
 	}
 	//conn, err := s.upgrader.Upgrade(w, r, nil)
@@ -140,14 +203,33 @@ func (s *Server) ServerWs(w http.ResponseWriter, r *http.Request) {
 
 	//对连接的鉴权
 	if !s.authentication.Auth(w, r) {
+		metricAuthFailures.Inc()
 		//conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprint("不具备访问权限")))
-		s.Send(&Message{FrameType: FrameData, Data: fmt.Sprint("不具备访问权限")}, conn)
+		// 连接未记录，直接写入后关闭
+		if data, err := conn.codec.Marshal(&Message{FrameType: FrameData, Data: fmt.Sprint("不具备访问权限")}); err == nil {
+			conn.WriteMessage(conn.codec.MessageType(), data)
+		}
 		conn.Close()
 		return
 	}
 
+	s.serveConn(conn, r)
+}
+
+// serveConn 记录鉴权通过的连接，并启动处理该连接的任务，WebSocket 及 HTTP 回退的连接共用。
+//
+// 参数:
+//   - conn: 鉴权通过的连接。
+//   - r: 建立连接的 HTTP 请求，用于获取用户 ID 及令牌。
+func (s *Server) serveConn(conn *Conn, r *http.Request) {
 	// 记录连接
 	s.addConn(conn, r)
+	if auth, ok := s.tokenAuth(); ok {
+		s.trackToken(conn, auth.Token(r))
+	}
+	go conn.keepalive()
+	go conn.writeLoop()
+	go s.deliverOffline(conn)
 
 	// 处理连接
 	go s.handlerConn(conn)
@@ -161,17 +243,9 @@ func (s *Server) ServerWs(w http.ResponseWriter, r *http.Request) {
 // 参数:
 //   - conn: WebSocket 连接对象，用于接收和发送消息。
 func (s *Server) handlerConn(conn *Conn) {
-
-	uids := s.GetUsers(conn)
-	conn.Uid = uids[0]
-
 	// 处理任务
 	go s.handlerWrite(conn)
 
-	if s.isAck(nil) {
-		go s.readAck(conn)
-	}
-
 	for {
 		// 获取请求消息
 		_, msg, err := conn.ReadMessage()
@@ -183,18 +257,24 @@ func (s *Server) handlerConn(conn *Conn) {
 		}
 		// 解析消息
 		var message Message
-		if err = json.Unmarshal(msg, &message); err != nil {
-			s.Errorf("json unmarshal err %v, msg %v", err, string(msg))
+		if err = conn.codec.Unmarshal(msg, &message); err != nil {
+			s.Errorf("%v unmarshal err %v, msg %v", conn.codec.Name(), err, string(msg))
 			s.Close(conn)
 			return
 		}
+		metricFramesIn.Inc(s.frameLabel(&message))
+
+		// 客户端对服务端推送的确认
+		if message.FrameType == FrameAck && conn.ackPush(message.Id) {
+			continue
+		}
 
 		// 依据消息进行处理
 		if s.isAck(&message) {
 			s.Infof("conn message read ack msg %v", message)
-			conn.appendMsgMq(&message)
+			s.receiveAck(conn, &message)
 		} else {
-			conn.message <- &message
+			conn.dispatch(&message)
 		}
 	}
 }
@@ -218,96 +298,6 @@ func (s *Server) isAck(message *Message) bool {
 	return s.opt.ack != NoAck && message.FrameType != FrameNoAck
 }
 
-// 读取消息的ack
-func (s *Server) readAck(conn *Conn) {
-	for {
-		select {
-		case <-conn.done:
-			s.Infof("close message ack uid %v ", conn.Uid)
-			return
-		default:
-		}
-
-		// 从队列中读取新的消息
-		conn.messageMu.Lock()
-		if len(conn.readMessage) == 0 {
-			conn.messageMu.Unlock()
-			// 增加睡眠
-			time.Sleep(100 * time.Microsecond)
-			continue
-		}
-
-		// 读取第一条
-		message := conn.readMessage[0]
-
-		// 判断ack的方式
-		switch s.opt.ack {
-		case OnlyAck:
-			// 直接给客户端回复
-			s.Send(&Message{
-				FrameType: FrameAck,
-				Id:        message.Id,
-				AckSeq:    message.AckSeq + 1,
-			}, conn)
-			// 进行业务处理
-			// 把消息从队列中移除
-			conn.readMessage = conn.readMessage[1:]
-			conn.messageMu.Unlock()
-
-			conn.message <- message
-		case RigorAck:
-			// 先回
-			if message.AckSeq == 0 {
-				// 还未确认
-				conn.readMessage[0].AckSeq++
-				conn.readMessage[0].ackTime = time.Now()
-				s.Send(&Message{
-					FrameType: FrameAck,
-					Id:        message.Id,
-					AckSeq:    message.AckSeq,
-				}, conn)
-				s.Infof("message ack RigorAck send mid %v, seq %v , time%v", message.Id, message.AckSeq,
-					message.ackTime)
-				conn.messageMu.Unlock()
-				continue
-			}
-
-			// 再验证
-
-			// 1. 客户端返回结果，再一次确认
-			// 得到客户端的序号
-			msgSeq := conn.readMessageSeq[message.Id]
-			if msgSeq.AckSeq > message.AckSeq {
-				// 确认
-				conn.readMessage = conn.readMessage[1:]
-				conn.messageMu.Unlock()
-				conn.message <- message
-				s.Infof("message ack RigorAck success mid %v", message.Id)
-				continue
-			}
-
-			// 2. 客户端没有确认，考虑是否超过了ack的确认时间
-			val := s.opt.ackTimeout - time.Since(message.ackTime)
-			if !message.ackTime.IsZero() && val <= 0 {
-				//		2.2 超过结束确认
-				delete(conn.readMessageSeq, message.Id)
-				conn.readMessage = conn.readMessage[1:]
-				conn.messageMu.Unlock()
-				continue
-			}
-			//		2.1 未超过，重新发送
-			conn.messageMu.Unlock()
-			s.Send(&Message{
-				FrameType: FrameAck,
-				Id:        message.Id,
-				AckSeq:    message.AckSeq,
-			}, conn)
-			// 睡眠一定的时间
-			time.Sleep(3 * time.Second)
-		}
-	}
-}
-
 // handleWrite 处理并分发消息任务。
 //
 // 该方法用于处理连接中的消息，并根据消息的 FrameType 分发到相应的处理器。
@@ -329,55 +319,110 @@ func (s *Server) handlerWrite(conn *Conn) {
 		case message := <-conn.message:
 			switch message.FrameType {
 			case FramePing:
-				s.Send(&Message{FrameType: FramePing}, conn)
+				s.Send(&Message{FrameType: FramePing, Id: message.Id}, conn)
 			case FrameData:
 				// 根据请求的method分发路由并执行
-				if handler, ok := s.routes[message.Method]; ok {
+				if handler, ok := s.handler(message.Method); ok {
+					start := time.Now()
 					handler(s, conn, message)
+					metricHandlerDuration.Observe(time.Since(start).Milliseconds(), message.Method)
+					s.routeStat(message.Method).observe(time.Since(start))
 				} else {
-					s.Send(&Message{FrameType: FrameData, Data: fmt.Sprintf("不存在执行的方法 %v 请检查", message.Method)}, conn)
+					s.routeStat(message.Method).calls.Add(1)
+					s.Send(newCodeErrMessage(message, xerr.METHOD_NOT_FOUND_ERROR), conn)
 					//conn.WriteMessage(&Message{}, []byte(fmt.Sprintf("不存在执行的方法 %v 请检查", message.Method)))
 				}
 			}
 
 			if s.isAck(message) {
-				conn.messageMu.Lock()
-				delete(conn.readMessageSeq, message.Id)
-				conn.messageMu.Unlock()
+				s.ackDone(conn, message.Id)
 			}
+			atomic.AddInt32(&conn.inflight, -1)
 		}
 	}
 }
 
-// addConn 存储 WebSocket 连接并与用户 ID 关联。
+// addConn 存储 WebSocket 连接并与用户 ID、设备 ID 关联。
 //
-// 该方法用于将新的 WebSocket 连接添加到服务器中，并将其与用户 ID 进行关联。
-// 如果用户 ID 对应的连接已存在，则关闭之前的连接。
-// 将新的连接与用户 ID 关联后，更新服务器的连接映射。
+// 该方法用于将新的 WebSocket 连接添加到服务器中，并将其与用户 ID 和设备 ID 进行关联。
+// 同一设备的旧连接总会被新连接替换，其余设备的连接由踢出策略 KickPolicy 决定是否关闭。
+// 如果配置了 Locator，会将连接所在的节点记录下来，用于集群间的消息路由；配置了 Presence 时记录设备在线。
 //
 // 参数:
 //   - conn: 要添加的 WebSocket 连接。
 //   - req: HTTP 请求，用于获取用户 ID。
 func (s *Server) addConn(conn *Conn, req *http.Request) {
 	uid := s.authentication.UserId(req)
+	conn.Uid = uid
+	_, conn.Internal = s.opt.internalUsers[uid]
 
 	s.RWMutex.Lock()
-	defer s.RWMutex.Unlock()
 
-	// 验证用户是否之前登入过
-	if c := s.userToConn[uid]; c != nil {
-		// 关闭之前的连接
+	devices := s.userToConn[uid]
+	if devices == nil {
+		devices = make(map[string]*Conn)
+		s.userToConn[uid] = devices
+		metricUsers.Inc()
+	}
+
+	// 同一设备重复登入，替换之前的连接
+	kicks := make([]*Conn, 0, 1)
+	if c := devices[conn.DeviceId]; c != nil {
+		kicks = append(kicks, c)
+	}
+
+	exists := make([]*Conn, 0, len(devices))
+	for deviceId, c := range devices {
+		if deviceId != conn.DeviceId {
+			exists = append(exists, c)
+		}
+	}
+	kicks = append(kicks, s.opt.kickPolicy(conn, exists)...)
+
+	// 关闭被踢下线的连接
+	for _, c := range kicks {
+		delete(s.connToUser, c)
+		delete(devices, c.DeviceId)
 		c.Close()
 	}
 
 	s.connToUser[conn] = uid
-	s.userToConn[uid] = conn
+	devices[conn.DeviceId] = conn
+	s.RWMutex.Unlock()
+	metricConnections.Add(float64(1 - len(kicks)))
+
+	// 同一设备的在线记录由新连接直接覆盖
+	for _, c := range kicks {
+		s.releasePushes(c)
+		s.untrackToken(c)
+		if c.DeviceId != conn.DeviceId {
+			s.presenceOffline(c)
+			s.runHooks(s.onClose, c)
+		}
+	}
+	s.presenceOnline(conn)
+	s.runHooks(s.onConnect, conn)
+
+	// 记录连接所在的节点，同一设备的记录由新连接直接覆盖；内部服务的连接不参与路由
+	if s.opt.locator == nil || conn.Internal {
+		return
+	}
+	for _, c := range kicks {
+		if c.DeviceId == conn.DeviceId {
+			continue
+		}
+		if err := s.opt.locator.Unregister(uid, c.DeviceId, s.opt.node); err != nil {
+			s.Errorf("locator unregister uid %v device %v err %v", uid, c.DeviceId, err)
+		}
+	}
+	if err := s.opt.locator.Register(uid, conn.DeviceId, s.opt.node); err != nil {
+		s.Errorf("locator register uid %v device %v err %v", uid, conn.DeviceId, err)
+	}
 }
 
 // GetConn 根据用户 ID 获取 WebSocket 连接。
 //
-// 该方法用于根据用户 ID 从服务器的用户到连接的映射中获取对应的 WebSocket 连接。
-// 如果找不到对应的连接，返回 nil。
+// 用户在多个设备上登录时，返回最近建立的连接；需要获取全部设备的连接请使用 GetConns。
 //
 // 参数:
 //   - uid: 用户的 ID。
@@ -388,19 +433,40 @@ func (s *Server) GetConn(uid string) *Conn {
 	s.RWMutex.RLock()
 	defer s.RWMutex.RUnlock()
 
-	return s.userToConn[uid]
+	var res *Conn
+	for _, c := range s.userToConn[uid] {
+		if res == nil || c.ConnectAt.After(res.ConnectAt) {
+			res = c
+		}
+	}
+	return res
+}
+
+// GetDeviceConn 根据用户 ID 和设备 ID 获取 WebSocket 连接。
+//
+// 参数:
+//   - uid: 用户的 ID。
+//   - deviceId: 设备的 ID。
+//
+// 返回:
+//   - *Conn: 对应设备的 WebSocket 连接；如果未找到，则返回 nil。
+func (s *Server) GetDeviceConn(uid, deviceId string) *Conn {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+
+	return s.userToConn[uid][deviceId]
 }
 
 // GetConns 根据用户 ID 列表获取 WebSocket 连接列表。
 //
-// 该方法用于根据用户 ID 列表从服务器的用户到连接的映射中获取对应的 WebSocket 连接列表。
-// 如果用户 ID 列表为空，返回一个空的连接列表。
+// 该方法会返回每个用户在所有设备上的连接，离线的用户不会出现在结果中。
+// 如果用户 ID 列表为空，返回 nil。
 //
 // 参数:
 //   - uids: 用户的 ID 列表。
 //
 // 返回:
-//   - []*Conn: 对应用户 ID 的 WebSocket 连接列表；如果用户 ID 列表为空，则返回 nil。
+//   - []*Conn: 对应用户 ID 的 WebSocket 连接列表。
 func (s *Server) GetConns(uids ...string) []*Conn {
 	if len(uids) == 0 {
 		return nil
@@ -411,7 +477,9 @@ func (s *Server) GetConns(uids ...string) []*Conn {
 
 	res := make([]*Conn, 0, len(uids))
 	for _, uid := range uids {
-		res = append(res, s.userToConn[uid])
+		for _, c := range s.userToConn[uid] {
+			res = append(res, c)
+		}
 	}
 	return res
 }
@@ -419,7 +487,7 @@ func (s *Server) GetConns(uids ...string) []*Conn {
 // GetUsers 获取与指定连接关联的用户 ID 列表。
 //
 // 该方法用于从服务器获取与连接相关的用户 ID 列表。
-// 如果没有指定连接，则返回所有用户的 ID 列表；如果指定了连接，则返回这些连接对应的用户 ID 列表。
+// 如果没有指定连接，则返回所有在线用户的 ID 列表（多端登录的用户只出现一次）；如果指定了连接，则返回这些连接对应的用户 ID 列表。
 //
 // 参数:
 //   - conns: 要获取用户 ID 的 WebSocket 连接列表。如果为空，则返回所有用户的 ID 列表。
@@ -434,8 +502,8 @@ func (s *Server) GetUsers(conns ...*Conn) []string {
 	var res []string
 	if len(conns) == 0 {
 		// 获取全部
-		res = make([]string, 0, len(s.connToUser))
-		for _, uid := range s.connToUser {
+		res = make([]string, 0, len(s.userToConn))
+		for uid := range s.userToConn {
 			res = append(res, uid)
 		}
 	} else {
@@ -453,29 +521,55 @@ func (s *Server) GetUsers(conns ...*Conn) []string {
 //
 // 该方法用于关闭 WebSocket 连接并从服务器的连接映射中删除该连接。
 // 如果连接已经被关闭（即用户 ID 为空），则不执行任何操作。
-// 关闭连接后，将从 `connToUser` 和 `userToConn` 映射中移除相关条目。
+// 关闭连接后，将从 `connToUser` 和 `userToConn` 映射中移除相关条目，用户的其他设备不受影响。
+// 如果配置了 Locator，同时移除该设备在当前节点上的连接记录。
 //
 // 参数:
 //   - conn: 要关闭的 WebSocket 连接。
 func (s *Server) Close(conn *Conn) {
 	s.RWMutex.Lock()
-	defer s.RWMutex.Unlock()
 
 	uid := s.connToUser[conn]
 	if uid == "" {
 		// 已经被关闭
+		s.RWMutex.Unlock()
 		return
 	}
 
 	delete(s.connToUser, conn)
-	delete(s.userToConn, uid)
+	removed := false
+	if devices := s.userToConn[uid]; devices != nil {
+		if devices[conn.DeviceId] == conn {
+			delete(devices, conn.DeviceId)
+			removed = true
+		}
+		if len(devices) == 0 {
+			delete(s.userToConn, uid)
+			metricUsers.Dec()
+		}
+	}
+	s.RWMutex.Unlock()
+	metricConnections.Dec()
 
 	conn.Close()
+	s.releasePushes(conn)
+	s.untrackToken(conn)
+
+	if removed {
+		s.presenceOffline(conn)
+		s.runHooks(s.onClose, conn)
+	}
+	if removed && s.opt.locator != nil && !conn.Internal {
+		if err := s.opt.locator.Unregister(uid, conn.DeviceId, s.opt.node); err != nil {
+			s.Errorf("locator unregister uid %v device %v err %v", uid, conn.DeviceId, err)
+		}
+	}
 }
 
 // SendByUserId 向指定的用户 ID 发送消息。
 //
 // 该方法用于将消息发送到指定的用户连接。首先，根据传入的用户 ID 获取对应的连接，然后将消息发送到这些连接中。
+// 用户在多个设备上登录时，消息会发送到该用户的所有设备。
 // 如果没有指定用户 ID，则不执行任何操作。
 //
 // 参数:
@@ -495,9 +589,9 @@ func (s *Server) SendByUserId(msg interface{}, sendIds ...string) error {
 // Send 向指定的连接发送消息。
 //
 // 该方法用于将消息发送到一个或多个 WebSocket 连接。
-// 首先将消息序列化为 JSON 格式，然后遍历连接列表，将消息通过 WebSocket 发送到每个连接中。
+// 使用每个连接协商的编解码器序列化消息，然后遍历连接列表，将消息放入每个连接的发送队列，由连接各自的写协程写入。
 // 如果没有指定连接，则不执行任何操作。
-// 如果在发送过程中发生错误，方法将立即返回该错误；如果成功，则返回 nil。
+// 某个连接发送失败不影响其余连接，所有失败的连接及原因以 SendErrors 返回；如果成功，则返回 nil。
 //
 // 参数:
 //   - msg: 要发送的消息，可以是任何类型的数据。
@@ -510,17 +604,34 @@ func (s *Server) Send(msg interface{}, conns ...*Conn) error {
 		return nil
 	}
 
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return err
+	// 不同的连接可能使用不同的编解码器，同一编解码器只编码一次
+	encoded := make(map[Codec][]byte, 1)
+	label := outboundLabel(msg)
+	if m, ok := msg.(*Message); ok && m.FrameType == FrameErr && m.Method != "" {
+		s.routeStat(m.Method).errors.Add(1)
 	}
-
+	var errs SendErrors
 	for _, conn := range conns {
-		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
-			return err
+		data, ok := encoded[conn.codec]
+		if !ok {
+			var err error
+			if data, err = conn.codec.Marshal(msg); err != nil {
+				return err
+			}
+			encoded[conn.codec] = data
+		}
+
+		if err := conn.enqueue(data); err != nil {
+			metricSendErrors.Inc(sendErrorReason(err))
+			errs = append(errs, &SendError{Conn: conn, Err: err})
+			continue
 		}
+		metricFramesOut.Inc(label)
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
@@ -537,20 +648,191 @@ func (s *Server) AddRoutes(rs []Route) {
 	}
 }
 
+// Use 添加全局中间件，作用于所有的路由，先添加的中间件在外层先执行。
+//
+// 全局中间件在路由自身的中间件（WithMiddlewares）之前执行，需要在服务启动之前添加。
+//
+// 参数:
+//   - ms: 需要添加的中间件。
+func (s *Server) Use(ms ...Middleware) {
+	s.middlewares = append(s.middlewares, ms...)
+}
+
+// OnConnect 添加用户连接建立后执行的回调，例如通知好友上线，需要在服务启动之前添加。
+func (s *Server) OnConnect(hooks ...ConnHookFunc) {
+	s.onConnect = append(s.onConnect, hooks...)
+}
+
+// OnClose 添加用户连接关闭（包括被踢下线）后执行的回调，需要在服务启动之前添加。
+func (s *Server) OnClose(hooks ...ConnHookFunc) {
+	s.onClose = append(s.onClose, hooks...)
+}
+
+// handler 获取方法对应的处理函数，并包装全局中间件。
+func (s *Server) handler(method string) (HandlerFunc, bool) {
+	handler, ok := s.routes[method]
+	if !ok {
+		return nil, false
+	}
+	return chain(s.middlewares, handler), true
+}
+
 // Start 启动服务器
 //
 // 该方法用于启动HTTP服务器并开始监听指定的地址。它将处理所有传入的请求，并调用
 // `ServerWs` 方法处理WebSocket连接。启动后，服务器将会持续运行，直到出现错误或
-// 被手动停止。
+// 调用 Stop 停止服务，停止服务时会等待连接全部处理完成后再返回。
+//
+// 配置了 WithServerTLS 时使用 TLS 监听，客户端通过 wss 连接。
 func (s *Server) Start() {
-	http.HandleFunc(s.patten, s.ServerWs)
-	s.Info(http.ListenAndServe(s.addr, nil))
+	s.Handler()
+
+	var err error
+	if s.opt.certFile != "" {
+		err = s.httpServer.ListenAndServeTLS(s.opt.certFile, s.opt.keyFile)
+	} else {
+		err = s.httpServer.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.Error(err)
+		return
+	}
+	<-s.stopped
+}
+
+// Handler 返回服务器使用的 http.Handler，包含 WebSocket 的路由及通过 Handle 添加的路由。
+//
+// 不调用 Start 时，可以将返回的 Handler 交给已有的 HTTP 服务使用。
+func (s *Server) Handler() http.Handler {
+	s.muxOnce.Do(func() {
+		s.register(s.mux)
+	})
+	return s.mux
+}
+
+// register 将 WebSocket、HTTP 回退及管理接口的路由添加到 mux 上。
+func (s *Server) register(mux *http.ServeMux) {
+	mux.HandleFunc(s.patten, s.ServerWs)
+	if s.opt.fallback != "" {
+		mux.Handle(s.opt.fallback+"/", http.StripPrefix(s.opt.fallback, s.fallbackHandler()))
+	}
+	if s.opt.adminPatten != "" && s.opt.adminToken != "" {
+		mux.Handle(s.opt.adminPatten+"/", http.StripPrefix(s.opt.adminPatten, s.adminHandler()))
+	}
+}
+
+// Handle 在服务器的监听地址上添加其他的 HTTP 路由，例如健康检查、监控及管理接口。
+//
+// 参数:
+//   - pattern: 路由的匹配规则，与 http.ServeMux 相同。
+//   - handler: 路由的处理函数。
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Mount 将 WebSocket 的路由挂载到已有的 http.ServeMux 上，由已有的 HTTP 服务负责监听。
+//
+// 挂载后不需要调用 Start，停止服务时仍需调用 Stop 关闭所有的连接。
+//
+// 参数:
+//   - mux: 已有的 http.ServeMux。
+func (s *Server) Mount(mux *http.ServeMux) {
+	s.register(mux)
 }
 
 // Stop 停止服务器
 //
-// 该方法用于停止正在运行的服务器。它会打印一条停止服务的消息。请注意，该方法
-// 只是打印了停止服务的消息，实际停止服务的操作可能需要额外的实现。
+// 该方法用于优雅地停止正在运行的服务器，多次调用只会执行一次：
+//  1. 停止接收新的连接。
+//  2. 向所有连接发送 FrameGoAway 消息，通知客户端重新连接到其他节点。
+//  3. 不再等待客户端的确认，等待已接收的消息处理完成。
+//  4. 关闭所有连接。
+//
+// 整个过程不超过 WithServerShutdownTimeout 设置的时间，超时后直接关闭连接。
 func (s *Server) Stop() {
-	fmt.Println("停止服务")
+	s.stopOnce.Do(func() {
+		defer close(s.stopped)
+
+		s.Info("停止服务")
+		s.draining.Store(true)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opt.shutdownTimeout)
+		defer cancel()
+
+		// 结束 HTTP 回退的推送请求，避免 Shutdown 等待这些请求结束；客户端重新请求时转到其他节点
+		close(s.streamStop)
+
+		// 停止接收新的连接
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			s.Errorf("http server shutdown err %v", err)
+		}
+
+		conns := s.allConns()
+
+		// 不再等待客户端的确认，直接处理已接收的消息
+		for _, conn := range conns {
+			s.flushAcks(conn)
+		}
+
+		// 通知客户端重新连接
+		for _, conn := range conns {
+			if err := s.Send(&Message{FrameType: FrameGoAway, Data: "服务停止，请重新连接"}, conn); err != nil {
+				s.Errorf("send go away uid %v err %v", conn.Uid, err)
+			}
+		}
+
+		// 等待消息处理完成
+		s.drain(ctx, conns)
+
+		for _, conn := range conns {
+			s.closeWithCode(conn, websocket.CloseGoingAway, "server shutdown")
+		}
+		s.scheduler.stop()
+	})
+}
+
+// drain 等待连接中已接收的消息处理完成，直到所有连接处理完成或超时。
+func (s *Server) drain(ctx context.Context, conns []*Conn) {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		pending := 0
+		for _, conn := range conns {
+			if !conn.drained() {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			s.Errorf("drain timeout, %v conns still pending", pending)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// allConns 获取当前服务器上的所有连接。
+func (s *Server) allConns() []*Conn {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+
+	res := make([]*Conn, 0, len(s.connToUser))
+	for conn := range s.connToUser {
+		res = append(res, conn)
+	}
+	return res
+}
+
+// closeWithCode 使用指定的关闭码关闭连接并从服务器中移除。
+func (s *Server) closeWithCode(conn *Conn, code int, text string) {
+	msg := websocket.FormatCloseMessage(code, text)
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second)); err != nil {
+		s.Errorf("write close message uid %v err %v", conn.Uid, err)
+	}
+	s.Close(conn)
 }
@@ -1,6 +1,9 @@
 package websocket
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // ServerOptions 定义 WebSocket 服务器的选项配置函数。
 type ServerOptions func(opt *serverOption)
@@ -8,14 +11,50 @@ type ServerOptions func(opt *serverOption)
 type serverOption struct {
 	Authentication
 
-	ack        AckType
-	ackTimeout time.Duration
+	ack              AckType
+	ackTimeout       time.Duration
+	ackRetryInterval time.Duration
+	ackWindow        int
 
 	patten string
 
 	maxConnectionIdle time.Duration
 
-	concurrency int
+	concurrency    int
+	broadcastBatch int
+
+	kickPolicy KickPolicy
+
+	locator        Locator
+	node           string
+	locatorRefresh time.Duration
+	presence       Presence
+
+	internalUsers map[string]struct{}
+
+	shutdownTimeout time.Duration
+
+	codec Codec
+
+	sendQueueSize  int
+	overflowPolicy OverflowPolicy
+	writeTimeout   time.Duration
+
+	pushAckTimeout time.Duration
+	pushRetries    int
+	offlineStore   OfflineStore
+
+	tokenWarn  time.Duration
+	tokenCheck time.Duration
+
+	fallback string
+
+	adminPatten string
+	adminToken  string
+
+	certFile     string
+	keyFile      string
+	allowOrigins []string
 }
 
 func newServerOptions(opts ...ServerOptions) serverOption {
@@ -23,8 +62,22 @@ func newServerOptions(opts ...ServerOptions) serverOption {
 		Authentication:    new(authentication),
 		maxConnectionIdle: defaultMaxConnectionIdle,
 		ackTimeout:        defaultAckTimeout,
+		ackRetryInterval:  defaultAckRetryInterval,
+		ackWindow:         defaultAckWindow,
 		patten:            "/ws",
 		concurrency:       defaultConcurrency,
+		broadcastBatch:    defaultBroadcastBatch,
+		kickPolicy:        KickSameDeviceType,
+		locatorRefresh:    defaultLocatorRefresh,
+		shutdownTimeout:   defaultShutdownTimeout,
+		codec:             JSONCodec,
+		sendQueueSize:     defaultSendQueueSize,
+		overflowPolicy:    DropOldest,
+		writeTimeout:      defaultWriteTimeout,
+		pushAckTimeout:    defaultPushAckTimeout,
+		pushRetries:       defaultPushRetries,
+		tokenWarn:         defaultTokenWarn,
+		tokenCheck:        defaultTokenCheck,
 	}
 
 	for _, opt := range opts {
@@ -51,6 +104,33 @@ func WithServerAck(ack AckType) ServerOptions {
 	}
 }
 
+// WithServerAckTimeout 设置 RigorAck 模式下等待客户端确认的超时时间，超时后放弃该消息。
+func WithServerAckTimeout(timeout time.Duration) ServerOptions {
+	return func(opt *serverOption) {
+		if timeout > 0 {
+			opt.ackTimeout = timeout
+		}
+	}
+}
+
+// WithServerAckRetryInterval 设置 RigorAck 模式下客户端未确认时重发确认的间隔。
+func WithServerAckRetryInterval(interval time.Duration) ServerOptions {
+	return func(opt *serverOption) {
+		if interval > 0 {
+			opt.ackRetryInterval = interval
+		}
+	}
+}
+
+// WithServerAckWindow 设置 RigorAck 模式下每个连接同时等待确认的消息数，超过后新消息排队等待。
+func WithServerAckWindow(window int) ServerOptions {
+	return func(opt *serverOption) {
+		if window > 0 {
+			opt.ackWindow = window
+		}
+	}
+}
+
 func WithServerMaxConnectionIdle(maxConnectionIdle time.Duration) ServerOptions {
 	return func(opt *serverOption) {
 		if maxConnectionIdle > 0 {
@@ -58,3 +138,178 @@ func WithServerMaxConnectionIdle(maxConnectionIdle time.Duration) ServerOptions
 		}
 	}
 }
+
+func WithServerKickPolicy(policy KickPolicy) ServerOptions {
+	return func(opt *serverOption) {
+		if policy != nil {
+			opt.kickPolicy = policy
+		}
+	}
+}
+
+// WithServerConcurrency 设置 TaskRunner 同时执行的任务数，例如广播时同时发送的批次数。
+func WithServerConcurrency(concurrency int) ServerOptions {
+	return func(opt *serverOption) {
+		if concurrency > 0 {
+			opt.concurrency = concurrency
+		}
+	}
+}
+
+// WithServerBroadcastBatch 设置广播时每批发送的连接数。
+func WithServerBroadcastBatch(batch int) ServerOptions {
+	return func(opt *serverOption) {
+		if batch > 0 {
+			opt.broadcastBatch = batch
+		}
+	}
+}
+
+// WithServerLocator 设置集群路由使用的 Locator，node 为当前节点对外的访问地址，为空时使用监听地址。
+//
+// 服务运行期间会定期在 Locator 中刷新当前节点的存活记录，用于向所有节点广播消息。
+func WithServerLocator(locator Locator, node string) ServerOptions {
+	return func(opt *serverOption) {
+		opt.locator = locator
+		opt.node = node
+	}
+}
+
+// WithServerPresence 设置维护用户在线状态的 Presence，连接记录与 Locator 按相同的间隔（WithServerLocatorRefresh）刷新。
+func WithServerPresence(presence Presence) ServerOptions {
+	return func(opt *serverOption) {
+		opt.presence = presence
+	}
+}
+
+// WithServerInternalUsers 设置内部服务使用的用户，例如 Dispatcher 转发消息使用的系统用户。
+//
+// 内部用户的连接 Internal 为 true，不记录到 Locator 及 Presence 中，也不受限流及封禁的限制。
+func WithServerInternalUsers(uids ...string) ServerOptions {
+	return func(opt *serverOption) {
+		if opt.internalUsers == nil {
+			opt.internalUsers = make(map[string]struct{}, len(uids))
+		}
+		for _, uid := range uids {
+			opt.internalUsers[uid] = struct{}{}
+		}
+	}
+}
+
+func WithServerLocatorRefresh(refresh time.Duration) ServerOptions {
+	return func(opt *serverOption) {
+		if refresh > 0 {
+			opt.locatorRefresh = refresh
+		}
+	}
+}
+
+// WithServerFallback 开启 HTTP 回退的传输方式，patten 为路由前缀，例如 /ws/http，为空时不开启。
+//
+// 无法建立 WebSocket 连接（例如代理禁止协议升级）的客户端可以通过 HTTP 请求发送消息，通过 SSE 或长轮询接收推送，
+// 与 WebSocket 连接共用路由、鉴权及推送的处理。
+func WithServerFallback(patten string) ServerOptions {
+	return func(opt *serverOption) {
+		opt.fallback = strings.TrimSuffix(patten, "/")
+	}
+}
+
+// WithServerAdmin 开启管理接口，patten 为路由前缀，例如 /admin，请求需要携带 Authorization: Bearer {token}。
+//
+// patten 或 token 为空时不开启。管理接口可以查看当前节点上的连接、断开连接及查看路由的调用统计。
+func WithServerAdmin(patten, token string) ServerOptions {
+	return func(opt *serverOption) {
+		opt.adminPatten = strings.TrimSuffix(patten, "/")
+		opt.adminToken = token
+	}
+}
+
+// WithServerShutdownTimeout 设置停止服务时等待消息处理完成的最长时间。
+func WithServerShutdownTimeout(timeout time.Duration) ServerOptions {
+	return func(opt *serverOption) {
+		if timeout > 0 {
+			opt.shutdownTimeout = timeout
+		}
+	}
+}
+
+// WithServerCodec 设置客户端未协商编解码器时默认使用的编解码器，默认为 JSONCodec。
+func WithServerCodec(codec Codec) ServerOptions {
+	return func(opt *serverOption) {
+		if codec != nil {
+			opt.codec = codec
+		}
+	}
+}
+
+// WithServerSendQueue 设置每个连接发送队列的容量及队列已满时的处理策略，默认丢弃最早的消息。
+func WithServerSendQueue(size int, policy OverflowPolicy) ServerOptions {
+	return func(opt *serverOption) {
+		if size > 0 {
+			opt.sendQueueSize = size
+		}
+		opt.overflowPolicy = policy
+	}
+}
+
+// WithServerWriteTimeout 设置写入单条消息的超时时间，超时的连接会被关闭。
+func WithServerWriteTimeout(timeout time.Duration) ServerOptions {
+	return func(opt *serverOption) {
+		if timeout > 0 {
+			opt.writeTimeout = timeout
+		}
+	}
+}
+
+// WithServerPushAck 设置推送消息等待客户端确认的超时时间及重发次数。
+func WithServerPushAck(timeout time.Duration, retries int) ServerOptions {
+	return func(opt *serverOption) {
+		if timeout > 0 {
+			opt.pushAckTimeout = timeout
+		}
+		if retries >= 0 {
+			opt.pushRetries = retries
+		}
+	}
+}
+
+// WithServerOfflineStore 设置保存未确认推送消息的 OfflineStore，未设置时未确认的消息会被丢弃。
+func WithServerOfflineStore(store OfflineStore) ServerOptions {
+	return func(opt *serverOption) {
+		opt.offlineStore = store
+	}
+}
+
+// WithServerTokenWarn 设置令牌过期前多久提醒客户端重新认证，鉴权实现 TokenAuthentication 时生效。
+func WithServerTokenWarn(d time.Duration) ServerOptions {
+	return func(opt *serverOption) {
+		if d > 0 {
+			opt.tokenWarn = d
+		}
+	}
+}
+
+// WithServerTokenCheck 设置检查令牌是否被吊销的间隔，小于等于 0 时不检查，鉴权实现 TokenAuthentication 时生效。
+func WithServerTokenCheck(interval time.Duration) ServerOptions {
+	return func(opt *serverOption) {
+		opt.tokenCheck = interval
+	}
+}
+
+// WithServerTLS 设置证书及私钥文件，Start 时使用 TLS 监听，客户端通过 wss 连接。
+func WithServerTLS(certFile, keyFile string) ServerOptions {
+	return func(opt *serverOption) {
+		opt.certFile = certFile
+		opt.keyFile = keyFile
+	}
+}
+
+// WithServerAllowOrigins 设置允许连接的浏览器来源（Origin），未设置时允许所有来源。
+//
+// 来源可以是完整的地址（https://im.example.com）、主机名（im.example.com），
+// 或以 *. 开头匹配所有子域名（*.example.com）；未携带 Origin 的非浏览器客户端不受限制。
+func WithServerAllowOrigins(origins ...string) ServerOptions {
+	return func(opt *serverOption) {
+		opt.allowOrigins = append(opt.allowOrigins, origins...)
+	}
+}
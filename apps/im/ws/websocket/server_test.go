@@ -0,0 +1,404 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"im-chat/easy-chat/pkg/xerr"
+)
+
+// testAuthentication 使用 query 参数或请求头 X-User-Id 作为用户标识
+type testAuthentication struct{}
+
+func (a *testAuthentication) Auth(w http.ResponseWriter, r *http.Request) bool {
+	return a.UserId(r) != ""
+}
+
+func (*testAuthentication) UserId(r *http.Request) string {
+	if uid := r.URL.Query().Get("userId"); uid != "" {
+		return uid
+	}
+	return r.Header.Get("X-User-Id")
+}
+
+// newTestServer 启动一个进程内的 websocket 服务
+func newTestServer(t *testing.T, opts ...ServerOptions) (*Server, *httptest.Server) {
+	t.Helper()
+
+	var srv *Server
+	hs := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.ServerWs(w, r)
+	}))
+
+	opts = append([]ServerOptions{
+		WithServerAuthentication(new(testAuthentication)),
+	}, opts...)
+	srv = NewServer(hs.Listener.Addr().String(), opts...)
+
+	hs.Start()
+	t.Cleanup(hs.Close)
+	return srv, hs
+}
+
+// dialTestServer 以指定的用户和设备连接到测试服务
+func dialTestServer(t *testing.T, hs *httptest.Server, uid, deviceId string, deviceType DeviceType) *websocket.Conn {
+	t.Helper()
+	return dialTestServerQuery(t, hs, uid, deviceId, deviceType, url.Values{})
+}
+
+// dialTestServerQuery 与 dialTestServer 相同，附带额外的 query 参数
+func dialTestServerQuery(t *testing.T, hs *httptest.Server, uid, deviceId string, deviceType DeviceType,
+	query url.Values) *websocket.Conn {
+	t.Helper()
+
+	query.Set("userId", uid)
+	query.Set("deviceId", deviceId)
+	query.Set("deviceType", string(deviceType))
+
+	u := "ws" + strings.TrimPrefix(hs.URL, "http") + "?" + query.Encode()
+	conn, _, err := websocket.DefaultDialer.Dial(u, nil)
+	if err != nil {
+		t.Fatalf("dial err %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// waitFor 等待条件成立
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("wait for condition timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_KickPolicy(t *testing.T) {
+	type device struct {
+		id  string
+		typ DeviceType
+	}
+
+	tests := []struct {
+		name    string
+		policy  KickPolicy
+		devices []device
+		want    int
+	}{
+		{
+			"single device", KickSingleDevice,
+			[]device{{"a", MobileDevice}, {"b", DesktopDevice}}, 1,
+		},
+		{
+			"same device type", KickSameDeviceType,
+			[]device{{"a", MobileDevice}, {"b", DesktopDevice}, {"c", MobileDevice}}, 2,
+		},
+		{
+			"same device id", KickNone,
+			[]device{{"a", MobileDevice}, {"a", MobileDevice}, {"b", MobileDevice}}, 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hs := newTestServer(t, WithServerKickPolicy(tt.policy))
+
+			for _, d := range tt.devices {
+				dialTestServer(t, hs, "u1", d.id, d.typ)
+				id := d.id
+				waitFor(t, func() bool { return srv.GetDeviceConn("u1", id) != nil })
+			}
+
+			waitFor(t, func() bool { return len(srv.GetConns("u1")) == tt.want })
+		})
+	}
+}
+
+func TestServer_SendByUserId(t *testing.T) {
+	srv, hs := newTestServer(t, WithServerKickPolicy(KickNone))
+
+	phone := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	pc := dialTestServer(t, hs, "u1", "pc", DesktopDevice)
+	waitFor(t, func() bool { return len(srv.GetConns("u1")) == 2 })
+
+	if err := srv.SendByUserId(NewMessage("u2", "hello"), "u1"); err != nil {
+		t.Fatalf("SendByUserId err %v", err)
+	}
+
+	for _, c := range []*websocket.Conn{phone, pc} {
+		var msg Message
+		c.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := c.ReadJSON(&msg); err != nil {
+			t.Fatalf("read err %v", err)
+		}
+		if msg.Data != "hello" {
+			t.Errorf("SendByUserId() data = %v, want hello", msg.Data)
+		}
+	}
+}
+
+func TestServer_Stop(t *testing.T) {
+	srv, hs := newTestServer(t)
+
+	c := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+
+	go srv.Stop()
+
+	var msg Message
+	c.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := c.ReadJSON(&msg); err != nil {
+		t.Fatalf("read err %v", err)
+	}
+	if msg.FrameType != FrameGoAway {
+		t.Errorf("Stop() frame type = %v, want %v", msg.FrameType, FrameGoAway)
+	}
+
+	_, _, err := c.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("Stop() close err = %v, want %v", err, websocket.CloseGoingAway)
+	}
+
+	waitFor(t, func() bool { return srv.GetConn("u1") == nil })
+
+	// 停止后不再接收新的连接
+	u := "ws" + strings.TrimPrefix(hs.URL, "http") + "?userId=u2"
+	_, resp, err := websocket.DefaultDialer.Dial(u, nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("dial after Stop() resp = %v, err = %v, want 503", resp, err)
+	}
+}
+
+func TestServer_SendErrors(t *testing.T) {
+	srv, hs := newTestServer(t, WithServerKickPolicy(KickNone))
+
+	phone := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	dialTestServer(t, hs, "u1", "pc", DesktopDevice)
+	waitFor(t, func() bool { return len(srv.GetConns("u1")) == 2 })
+
+	closed := srv.GetDeviceConn("u1", "pc")
+	srv.Close(closed)
+
+	err := srv.Send(NewMessage("u2", "hello"), closed, srv.GetDeviceConn("u1", "phone"))
+	var errs SendErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Conn != closed || !errors.Is(err, ErrConnClosed) {
+		t.Fatalf("Send() err = %v, want ErrConnClosed for closed conn", err)
+	}
+
+	// 失败的连接不影响其余的连接
+	var msg Message
+	phone.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := phone.ReadJSON(&msg); err != nil || msg.Data != "hello" {
+		t.Errorf("read = %v, %v, want hello", msg.Data, err)
+	}
+}
+
+func TestServer_SlowConsumer(t *testing.T) {
+	srv, hs := newTestServer(t, WithServerSendQueue(1, DisconnectSlowConsumer))
+
+	// 客户端不读取消息，写入阻塞后发送队列很快就会被填满
+	dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+
+	data := strings.Repeat("x", 1<<20)
+	var err error
+	for i := 0; i < 64 && err == nil; i++ {
+		err = srv.SendByUserId(NewMessage("u2", data), "u1")
+	}
+	if !errors.Is(err, ErrSlowConsumer) {
+		t.Fatalf("SendByUserId() err = %v, want %v", err, ErrSlowConsumer)
+	}
+	// 在新的协程中断开连接
+	waitFor(t, func() bool { return srv.GetConn("u1") == nil })
+}
+
+func TestServer_SendWithAck(t *testing.T) {
+	store := NewMemoryOfflineStore()
+	srv, hs := newTestServer(t, WithServerPushAck(50*time.Millisecond, 1), WithServerOfflineStore(store))
+
+	read := func(c *websocket.Conn) Message {
+		t.Helper()
+		var msg Message
+		c.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := c.ReadJSON(&msg); err != nil {
+			t.Fatalf("read err %v", err)
+		}
+		return msg
+	}
+
+	pushAck := url.Values{"pushAck": {"1"}}
+	phone := dialTestServerQuery(t, hs, "u1", "phone", MobileDevice, pushAck)
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+
+	if err := srv.SendWithAck(NewMessage("u2", "hello"), srv.GetConn("u1")); err != nil {
+		t.Fatalf("SendWithAck() err %v", err)
+	}
+
+	// 未确认时重发一次，之后保存到离线消息中
+	first, second := read(phone), read(phone)
+	if first.Id == "" || first.Id != second.Id {
+		t.Fatalf("retransmit id = %v, want %v", second.Id, first.Id)
+	}
+	phone.Close()
+	waitFor(t, func() bool { return srv.GetConn("u1") == nil })
+
+	// 同一设备重新连接后再次推送，确认后不再重发
+	phone = dialTestServerQuery(t, hs, "u1", "phone", MobileDevice, pushAck)
+	msg := read(phone)
+	if msg.Id != first.Id || msg.Data != "hello" {
+		t.Fatalf("offline message = %+v, want id %v", msg, first.Id)
+	}
+	if err := phone.WriteJSON(&Message{FrameType: FrameAck, Id: msg.Id}); err != nil {
+		t.Fatalf("write ack err %v", err)
+	}
+
+	phone.SetReadDeadline(time.Now().Add(150 * time.Millisecond))
+	if err := phone.ReadJSON(&msg); err == nil {
+		t.Errorf("acked message retransmitted %+v", msg)
+	}
+	if msgs, _ := store.Load("u1", "phone"); len(msgs) != 0 {
+		t.Errorf("offline messages = %v, want empty", msgs)
+	}
+}
+
+func TestServer_SendWithAckOptIn(t *testing.T) {
+	store := NewMemoryOfflineStore()
+	srv, hs := newTestServer(t, WithServerPushAck(50*time.Millisecond, 1), WithServerOfflineStore(store))
+
+	// 未声明 pushAck 的客户端只收到一次，不重发也不保存离线消息
+	web := dialTestServer(t, hs, "u1", "web", WebDevice)
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+
+	if err := srv.SendWithAck(NewMessage("u2", "hello"), srv.GetConn("u1")); err != nil {
+		t.Fatalf("SendWithAck() err %v", err)
+	}
+
+	var msg Message
+	web.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := web.ReadJSON(&msg); err != nil || msg.Data != "hello" {
+		t.Fatalf("read = %+v, err %v", msg, err)
+	}
+	web.SetReadDeadline(time.Now().Add(150 * time.Millisecond))
+	if err := web.ReadJSON(&msg); err == nil {
+		t.Errorf("message retransmitted to client without pushAck %+v", msg)
+	}
+	if msgs, _ := store.Load("u1", "web"); len(msgs) != 0 {
+		t.Errorf("offline messages = %v, want empty", msgs)
+	}
+
+	// 没有任何连接的用户，任意设备连接后收到离线消息
+	srv.SaveOffline("u3", NewMessage("u2", "offline"))
+	phone := dialTestServer(t, hs, "u3", "phone", MobileDevice)
+	phone.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := phone.ReadJSON(&msg); err != nil || msg.Data != "offline" {
+		t.Fatalf("read offline = %+v, err %v", msg, err)
+	}
+	if msgs, _ := store.Load("u3", OfflineAnyDevice); len(msgs) != 0 {
+		t.Errorf("offline messages = %v, want empty", msgs)
+	}
+}
+
+func TestServer_AllowOrigins(t *testing.T) {
+	check := checkOrigin([]string{"https://im.example.com", "*.chat.com", "localhost:8080"})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"", true},
+		{"https://im.example.com", true},
+		{"http://im.example.com", false},
+		{"https://web.chat.com", true},
+		{"https://chat.com.evil.com", false},
+		{"http://localhost:8080", true},
+		{"http://localhost:9090", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := check(r); got != tt.want {
+			t.Errorf("check origin %q = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestServer_MountTLS(t *testing.T) {
+	srv := NewServer("", WithServerAuthentication(new(testAuthentication)))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+	srv.Mount(mux)
+
+	hs := httptest.NewTLSServer(mux)
+	t.Cleanup(hs.Close)
+	t.Cleanup(srv.Stop)
+
+	header := http.Header{}
+	header.Set("X-User-Id", "u1")
+	c := NewClient(strings.TrimPrefix(hs.URL, "https://"),
+		WithClientHeader(header),
+		WithClientTLS(hs.Client().Transport.(*http.Transport).TLSClientConfig),
+	)
+	defer c.Close()
+
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+	if resp, err := hs.Client().Get(hs.URL + "/healthz"); err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("healthz = %v, %v", resp, err)
+	}
+}
+
+func TestServer_Reply(t *testing.T) {
+	srv, hs := newTestServer(t)
+	srv.AddRoutes([]Route{
+		{Method: "ok", Handler: func(srv *Server, conn *Conn, msg *Message) {
+			srv.Send(NewResultMessage(msg, "done"), conn)
+		}},
+		{Method: "fail", Handler: func(srv *Server, conn *Conn, msg *Message) {
+			srv.Send(NewErrReply(msg, errors.New("internal detail")), conn)
+		}},
+	})
+
+	c := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	c.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	type reply struct {
+		FrameType `json:"frameType"`
+		Id        string `json:"id"`
+		Method    string `json:"method"`
+		Data      any    `json:"data"`
+	}
+	tests := []struct {
+		method string
+		want   reply
+	}{
+		{"ok", reply{FrameType: FrameResult, Id: "1", Method: "ok", Data: "done"}},
+		{"fail", reply{FrameType: FrameErr, Id: "2", Method: "fail",
+			Data: map[string]any{"code": float64(xerr.SERVER_COMMON_ERROR), "msg": xerr.ErrMsg(xerr.SERVER_COMMON_ERROR)}}},
+		{"missing", reply{FrameType: FrameErr, Id: "3", Method: "missing",
+			Data: map[string]any{"code": float64(xerr.METHOD_NOT_FOUND_ERROR), "msg": xerr.ErrMsg(xerr.METHOD_NOT_FOUND_ERROR)}}},
+	}
+	for i, tt := range tests {
+		if err := c.WriteJSON(&Message{FrameType: FrameData, Id: tt.want.Id, Method: tt.method}); err != nil {
+			t.Fatalf("write err %v", err)
+		}
+		var got reply
+		if err := c.ReadJSON(&got); err != nil {
+			t.Fatalf("read err %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%d: reply = %+v, want %+v", i, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"im-chat/easy-chat/pkg/xerr"
+)
+
+const (
+	// CloseTokenExpired 令牌过期时关闭连接使用的状态码。
+	CloseTokenExpired = 4001
+	// CloseTokenRevoked 令牌被吊销（例如账号被锁定）时关闭连接使用的状态码。
+	CloseTokenRevoked = 4003
+
+	// AuthRefreshMethod 客户端在连接内提交新令牌重新认证的方法，消息体为 AuthRefresh。
+	AuthRefreshMethod = "auth.refresh"
+	// AuthExpiringMethod 令牌即将过期时服务端推送的提醒，消息体为 AuthExpiring。
+	AuthExpiringMethod = "auth.expiring"
+)
+
+// ErrTokenRevoked 令牌已被吊销。
+var ErrTokenRevoked = errors.New("websocket token revoked")
+
+// TokenInfo 连接当前使用的令牌信息。
+//
+// 字段:
+//   - Uid: 令牌对应的用户。
+//   - IssuedAt: 令牌的签发时间，用于判断是否被吊销。
+//   - ExpireAt: 令牌的过期时间，零值表示不会过期。
+type TokenInfo struct {
+	Uid      string
+	IssuedAt time.Time
+	ExpireAt time.Time
+}
+
+// TokenAuthentication 支持令牌过期及连接内重新认证的鉴权接口。
+//
+// 鉴权实现该接口后，服务器会在令牌过期前推送 AuthExpiringMethod 提醒，客户端可以通过 AuthRefreshMethod 提交新的令牌；
+// 令牌过期后以 CloseTokenExpired 关闭连接，被吊销后以 CloseTokenRevoked 关闭连接。
+type TokenAuthentication interface {
+	Authentication
+	// Token 返回 Auth 验证通过的令牌信息。
+	Token(r *http.Request) TokenInfo
+	// Refresh 验证客户端提交的新令牌，令牌被吊销时返回 ErrTokenRevoked。
+	Refresh(token string) (TokenInfo, error)
+	// Revoked 判断用户在 issuedAt 签发的令牌是否已被吊销。
+	Revoked(uid string, issuedAt time.Time) bool
+}
+
+// AuthRefresh 客户端重新认证的消息体。
+type AuthRefresh struct {
+	Token string `mapstructure:"token" json:"token"`
+}
+
+// AuthExpiring 令牌过期时间的提醒，重新认证成功后也会返回该消息体。
+type AuthExpiring struct {
+	// 令牌的过期时间（Unix 秒），0 表示不会过期
+	ExpireAt int64 `mapstructure:"expireAt" json:"expireAt"`
+}
+
+// tokenAuth 返回支持令牌过期的鉴权，未实现 TokenAuthentication 时返回 false。
+func (s *Server) tokenAuth() (TokenAuthentication, bool) {
+	auth, ok := s.authentication.(TokenAuthentication)
+	return auth, ok
+}
+
+// trackToken 记录连接的令牌，并在令牌过期前提醒客户端、过期后关闭连接，重新认证后替换之前的定时任务。
+func (s *Server) trackToken(conn *Conn, token TokenInfo) {
+	conn.authMu.Lock()
+	defer conn.authMu.Unlock()
+
+	s.scheduler.cancel(conn.warnItem)
+	s.scheduler.cancel(conn.expireItem)
+	conn.token = token
+	conn.warnItem, conn.expireItem = nil, nil
+	if token.ExpireAt.IsZero() {
+		return
+	}
+
+	// 定时任务在调度器的协程中执行，不能阻塞
+	remain := time.Until(token.ExpireAt)
+	conn.warnItem = s.scheduler.schedule(max(remain-s.opt.tokenWarn, 0), func() {
+		go s.warnToken(conn, token)
+	})
+	conn.expireItem = s.scheduler.schedule(remain, func() {
+		go s.expireToken(conn, token)
+	})
+}
+
+// untrackToken 连接关闭后取消令牌的定时任务。
+func (s *Server) untrackToken(conn *Conn) {
+	conn.authMu.Lock()
+	defer conn.authMu.Unlock()
+
+	s.scheduler.cancel(conn.warnItem)
+	s.scheduler.cancel(conn.expireItem)
+	conn.warnItem, conn.expireItem = nil, nil
+}
+
+// currentToken 判断 token 是否仍是连接当前使用的令牌，重新认证后之前的定时任务不再生效。
+func (c *Conn) currentToken(token TokenInfo) bool {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	return c.token == token
+}
+
+func (s *Server) warnToken(conn *Conn, token TokenInfo) {
+	if !conn.currentToken(token) {
+		return
+	}
+	s.Send(&Message{
+		FrameType: FrameData,
+		Method:    AuthExpiringMethod,
+		Data:      newAuthExpiring(token),
+	}, conn)
+}
+
+func newAuthExpiring(token TokenInfo) *AuthExpiring {
+	if token.ExpireAt.IsZero() {
+		return &AuthExpiring{}
+	}
+	return &AuthExpiring{ExpireAt: token.ExpireAt.Unix()}
+}
+
+func (s *Server) expireToken(conn *Conn, token TokenInfo) {
+	if !conn.currentToken(token) {
+		return
+	}
+	s.Infof("token expired uid %v device %v", conn.Uid, conn.DeviceId)
+	s.closeWithCode(conn, CloseTokenExpired, "token expired")
+}
+
+// checkRevoked 检查连接的令牌是否已被吊销，被吊销时关闭连接。
+//
+// 返回:
+//   - bool: 令牌是否已被吊销。
+func (s *Server) checkRevoked(conn *Conn) bool {
+	auth, ok := s.tokenAuth()
+	if !ok {
+		return false
+	}
+
+	conn.authMu.Lock()
+	token := conn.token
+	conn.authMu.Unlock()
+
+	if !auth.Revoked(conn.Uid, token.IssuedAt) {
+		return false
+	}
+	s.Infof("token revoked uid %v device %v", conn.Uid, conn.DeviceId)
+	s.closeWithCode(conn, CloseTokenRevoked, "token revoked")
+	return true
+}
+
+// Revoke 吊销用户的令牌，以 CloseTokenRevoked 关闭用户在当前节点上的所有连接。
+//
+// 其他节点上的连接在下一次检查（WithServerTokenCheck）时关闭，前提是鉴权的 Revoked 能感知到吊销。
+//
+// 参数:
+//   - uids: 需要吊销令牌的用户 ID 列表。
+func (s *Server) Revoke(uids ...string) {
+	for _, conn := range s.GetConns(uids...) {
+		s.closeWithCode(conn, CloseTokenRevoked, "token revoked")
+	}
+}
+
+// authRefresh 处理客户端在连接内的重新认证。
+//
+// 新的令牌必须属于连接的用户，认证成功后按照新令牌的过期时间重新计时，并返回新的过期时间。
+func (s *Server) authRefresh(srv *Server, conn *Conn, msg *Message) {
+	auth, ok := s.tokenAuth()
+	if !ok {
+		return
+	}
+
+	var data AuthRefresh
+	if err := conn.Bind(msg, &data); err != nil || data.Token == "" {
+		s.Send(newCodeErrMessage(msg, xerr.REQUEST_PARAM_ERROR), conn)
+		return
+	}
+
+	token, err := auth.Refresh(data.Token)
+	switch {
+	case errors.Is(err, ErrTokenRevoked):
+		s.Send(newCodeErrMessage(msg, xerr.TOKEN_INVALID_ERROR), conn)
+		s.closeWithCode(conn, CloseTokenRevoked, "token revoked")
+		return
+	case err != nil:
+		s.Errorf("auth refresh uid %v err %v", conn.Uid, err)
+		s.Send(newCodeErrMessage(msg, xerr.TOKEN_INVALID_ERROR), conn)
+		return
+	case token.Uid != conn.Uid:
+		s.Errorf("auth refresh uid %v mismatch token uid %v", conn.Uid, token.Uid)
+		s.Send(newCodeErrMessage(msg, xerr.TOKEN_INVALID_ERROR), conn)
+		return
+	}
+
+	s.trackToken(conn, token)
+	s.Send(&Message{
+		FrameType: FrameData,
+		Id:        msg.Id,
+		Method:    AuthRefreshMethod,
+		Data:      newAuthExpiring(token),
+	}, conn)
+}
@@ -0,0 +1,118 @@
+package websocket
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// testTokenAuthentication 令牌在 ttl 后过期，提交 refreshToken 可以延长一小时
+type testTokenAuthentication struct {
+	testAuthentication
+	ttl     time.Duration
+	revoked atomic.Bool
+}
+
+const refreshToken = "refresh"
+
+func (a *testTokenAuthentication) Token(r *http.Request) TokenInfo {
+	now := time.Now()
+	return TokenInfo{Uid: a.UserId(r), IssuedAt: now, ExpireAt: now.Add(a.ttl)}
+}
+
+func (a *testTokenAuthentication) Refresh(token string) (TokenInfo, error) {
+	if token != refreshToken {
+		return TokenInfo{}, ErrTokenRevoked
+	}
+	now := time.Now()
+	return TokenInfo{Uid: "u1", IssuedAt: now, ExpireAt: now.Add(time.Hour)}, nil
+}
+
+func (a *testTokenAuthentication) Revoked(uid string, issuedAt time.Time) bool {
+	return a.revoked.Load()
+}
+
+func TestServer_TokenExpire(t *testing.T) {
+	auth := &testTokenAuthentication{ttl: 300 * time.Millisecond}
+	_, hs := newTestServer(t,
+		WithServerAuthentication(auth),
+		WithServerTokenWarn(200*time.Millisecond),
+		WithServerTokenCheck(100*time.Millisecond),
+	)
+
+	refreshed := dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	expired := dialTestServer(t, hs, "u2", "phone", MobileDevice)
+	refreshed.SetReadDeadline(time.Now().Add(2 * time.Second))
+	expired.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	// 过期前收到提醒
+	var msg Message
+	if err := refreshed.ReadJSON(&msg); err != nil || msg.Method != AuthExpiringMethod {
+		t.Fatalf("read = %+v, %v, want auth expiring", msg, err)
+	}
+
+	// 重新认证后延长过期时间
+	if err := refreshed.WriteJSON(&Message{FrameType: FrameData, Id: "1", Method: AuthRefreshMethod,
+		Data: &AuthRefresh{Token: refreshToken}}); err != nil {
+		t.Fatalf("write err %v", err)
+	}
+	msg = Message{}
+	if err := refreshed.ReadJSON(&msg); err != nil || msg.Method != AuthRefreshMethod || msg.Id != "1" {
+		t.Fatalf("read = %+v, %v, want auth refresh", msg, err)
+	}
+
+	// 未重新认证的连接过期后被关闭
+	for {
+		_, _, err := expired.ReadMessage()
+		if err == nil {
+			continue
+		}
+		if !websocket.IsCloseError(err, CloseTokenExpired) {
+			t.Fatalf("expired conn err %v, want close %v", err, CloseTokenExpired)
+		}
+		break
+	}
+
+	if err := refreshed.WriteJSON(&Message{FrameType: FramePing}); err != nil {
+		t.Fatalf("write err %v", err)
+	}
+	msg = Message{}
+	if err := refreshed.ReadJSON(&msg); err != nil || msg.FrameType != FramePing {
+		t.Fatalf("read = %+v, %v, want ping", msg, err)
+	}
+
+	// 令牌被吊销后关闭连接
+	auth.revoked.Store(true)
+	for {
+		_, _, err := refreshed.ReadMessage()
+		if err == nil {
+			continue
+		}
+		if !websocket.IsCloseError(err, CloseTokenRevoked) {
+			t.Fatalf("refreshed conn err %v, want close %v", err, CloseTokenRevoked)
+		}
+		break
+	}
+}
+
+func TestServer_TokenKick(t *testing.T) {
+	srv, hs := newTestServer(t, WithServerAuthentication(&testTokenAuthentication{ttl: time.Hour}))
+
+	dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	waitFor(t, func() bool { return srv.GetConn("u1") != nil })
+	old := srv.GetConn("u1")
+
+	// 同一设备重复登入，被踢下线的连接不再保留令牌的定时任务
+	dialTestServer(t, hs, "u1", "phone", MobileDevice)
+	waitFor(t, func() bool { return srv.GetConn("u1") != old })
+
+	srv.scheduler.mu.Lock()
+	n := len(srv.scheduler.items)
+	srv.scheduler.mu.Unlock()
+	if n != 2 {
+		t.Errorf("scheduled items = %v, want 2 for the new conn", n)
+	}
+}
@@ -0,0 +1,120 @@
+package ws
+
+import (
+	"google.golang.org/protobuf/proto"
+	"im-chat/easy-chat/apps/im/ws/ws/wspb"
+	"im-chat/easy-chat/pkg/constants"
+)
+
+// 以下方法实现 websocket.ProtoMarshaler 和 websocket.ProtoUnmarshaler，
+// 使用 protobuf 编解码器时消息体以二进制的形式传输，对应的定义见 ws.proto。
+
+func (m Msg) toProto() *wspb.Msg {
+	return &wspb.Msg{
+		MType:       int32(m.MType),
+		Content:     m.Content,
+		MsgId:       m.MsgId,
+		ReadRecords: m.ReadRecords,
+		ClientMsgId: m.ClientMsgId,
+		Seq:         m.Seq,
+	}
+}
+
+func (m *Msg) fromProto(pb *wspb.Msg) {
+	m.MType = constants.MType(pb.GetMType())
+	m.Content = pb.GetContent()
+	m.MsgId = pb.GetMsgId()
+	m.ReadRecords = pb.GetReadRecords()
+	m.ClientMsgId = pb.GetClientMsgId()
+	m.Seq = pb.GetSeq()
+}
+
+func (c Chat) MarshalProto() ([]byte, error) {
+	return proto.Marshal(&wspb.Chat{
+		ConversationId: c.ConversationId,
+		ChatType:       int32(c.ChatType),
+		SendId:         c.SendId,
+		RecvId:         c.RecvId,
+		SendTime:       c.SendTime,
+		Msg:            c.Msg.toProto(),
+	})
+}
+
+func (c *Chat) UnmarshalProto(data []byte) error {
+	var pb wspb.Chat
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+
+	c.ConversationId = pb.ConversationId
+	c.ChatType = constants.ChatType(pb.ChatType)
+	c.SendId = pb.SendId
+	c.RecvId = pb.RecvId
+	c.SendTime = pb.SendTime
+	c.Msg.fromProto(pb.Msg)
+	return nil
+}
+
+func (p Push) MarshalProto() ([]byte, error) {
+	return proto.Marshal(&wspb.Push{
+		ConversationId: p.ConversationId,
+		ChatType:       int32(p.ChatType),
+		SendId:         p.SendId,
+		SendDeviceId:   p.SendDeviceId,
+		RecvId:         p.RecvId,
+		RecvIds:        p.RecvIds,
+		SendTime:       p.SendTime,
+		MsgId:          p.MsgId,
+		ReadRecords:    p.ReadRecords,
+		ContentType:    int32(p.ContentType),
+		MType:          int32(p.MType),
+		Content:        p.Content,
+		ClientMsgId:    p.ClientMsgId,
+		Seq:            p.Seq,
+	})
+}
+
+func (p *Push) UnmarshalProto(data []byte) error {
+	var pb wspb.Push
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+
+	p.ConversationId = pb.ConversationId
+	p.ChatType = constants.ChatType(pb.ChatType)
+	p.SendId = pb.SendId
+	p.SendDeviceId = pb.SendDeviceId
+	p.RecvId = pb.RecvId
+	p.RecvIds = pb.RecvIds
+	p.SendTime = pb.SendTime
+	p.MsgId = pb.MsgId
+	p.ReadRecords = pb.ReadRecords
+	p.ContentType = constants.ContentType(pb.ContentType)
+	p.MType = constants.MType(pb.MType)
+	p.Content = pb.Content
+	p.ClientMsgId = pb.ClientMsgId
+	p.Seq = pb.Seq
+	return nil
+}
+
+func (m MarkRead) MarshalProto() ([]byte, error) {
+	return proto.Marshal(&wspb.MarkRead{
+		ChatType:       int32(m.ChatType),
+		RecvId:         m.RecvId,
+		ConversationId: m.ConversationId,
+		MsgIds:         m.MsgIds,
+	})
+}
+
+func (m *MarkRead) UnmarshalProto(data []byte) error {
+	var pb wspb.MarkRead
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+
+	m.ChatType = constants.ChatType(pb.ChatType)
+	m.RecvId = pb.RecvId
+	m.ConversationId = pb.ConversationId
+	m.MsgIds = pb.MsgIds
+	return nil
+}
@@ -5,11 +5,15 @@ import "im-chat/easy-chat/pkg/constants"
 type (
 	// Msg 表示一个基础消息的结构体。
 	//
-	// 该结构体包含消息的唯一标识符、已读记录、消息类型和消息内容
+	// 该结构体包含消息的唯一标识符、已读记录、消息类型和消息内容。
+	// MsgId 为服务端分配的消息ID；ClientMsgId 为客户端生成的消息ID，客户端重发时保持不变，服务端据此去重。
+	// Seq 为会话内单调递增且连续的消息序号，客户端收到的序号不连续时说明有消息缺失，可以据此补拉并排序。
 	Msg struct {
 		constants.MType `mapstructure:"mType"`
 		Content         string            `mapstructure:"content"`
 		MsgId           string            `mapstructure:"msgId"`
+		ClientMsgId     string            `mapstructure:"clientMsgId"`
+		Seq             int64             `mapstructure:"seq"`
 		ReadRecords     map[string]string `mapstructure:"readRecords"`
 	}
 
@@ -28,15 +32,20 @@ type (
 	// Push 表示一个推送消息的结构体。
 	//
 	// 该结构体包含了推送消息所需的信息，包括会话ID、发送者和接收者ID列表、发送时间、消息内容等。
+	// SendDeviceId 为发送消息的设备，消息会同步到发送者的其他设备上。
+	// 节点之间转发时 RecvIds 为目标节点上的接收者，私聊时接收者不在目标节点上则为空。
 	Push struct {
 		ConversationId     string `mapstructure:"conversationId"`
 		constants.ChatType `mapstructure:"chatType"`
 		SendId             string   `mapstructure:"sendId"`
+		SendDeviceId       string   `mapstructure:"sendDeviceId"`
 		RecvId             string   `mapstructure:"recvId"`
 		RecvIds            []string `mapstructure:"recvIds"`
 		SendTime           int64    `mapstructure:"sendTime"`
 
 		MsgId       string                `mapstructure:"msgId"`
+		ClientMsgId string                `mapstructure:"clientMsgId"`
+		Seq         int64                 `mapstructure:"seq"`
 		ReadRecords map[string]string     `mapstructure:"readRecords"`
 		ContentType constants.ContentType `mapstructure:"contentType"`
 
@@ -53,4 +62,81 @@ type (
 		ConversationId     string   `mapstructure:"conversationId"`
 		MsgIds             []string `mapstructure:"msgIds"`
 	}
+
+	// Event 表示一个不持久化的会话事件，例如正在输入。
+	//
+	// 事件只转发给在线的会话成员，不写入聊天记录，也不保存离线消息；超过 ExpireAt（毫秒时间戳）的事件会被丢弃，
+	// 客户端也应在 ExpireAt 之后自动清除事件的状态（例如停止显示“正在输入”）。
+	Event struct {
+		ConversationId     string `mapstructure:"conversationId"`
+		constants.ChatType `mapstructure:"chatType"`
+		SendId             string   `mapstructure:"sendId"`
+		RecvId             string   `mapstructure:"recvId"`
+		RecvIds            []string `mapstructure:"recvIds"`
+		EventType          string   `mapstructure:"eventType"`
+		Content            string   `mapstructure:"content"`
+		ExpireAt           int64    `mapstructure:"expireAt"`
+	}
+
+	// Presence 表示用户在线状态的变化，推送给该用户在线的好友及群成员。
+	//
+	// State 为 online、away、busy 或 offline，隐身的用户显示为 offline；LastSeen 为最后在线的时间（Unix 秒）。
+	// RecvIds 仅在节点之间转发时使用。
+	Presence struct {
+		Uid      string   `mapstructure:"uid"`
+		State    string   `mapstructure:"state"`
+		LastSeen int64    `mapstructure:"lastSeen"`
+		RecvIds  []string `mapstructure:"recvIds"`
+	}
+
+	// PresenceState 表示用户设置的在线状态，可以为 online、away、busy 或 invisible。
+	PresenceState struct {
+		State string `mapstructure:"state"`
+	}
+
+	// Broadcast 表示一个系统公告，由 im.rpc 投递到所有 im.ws 节点，各节点向投递范围内的连接广播。
+	//
+	// Segment 为投递范围：所有用户、GroupId 群的成员或使用 Version 客户端版本的用户；ExpireAt 为过期时间（毫秒时间戳），为 0 时不过期。
+	Broadcast struct {
+		Id                         string `mapstructure:"id"`
+		SendId                     string `mapstructure:"sendId"`
+		Title                      string `mapstructure:"title"`
+		Content                    string `mapstructure:"content"`
+		constants.BroadcastSegment `mapstructure:"segment"`
+		GroupId                    string `mapstructure:"groupId"`
+		Version                    string `mapstructure:"version"`
+		SendTime                   int64  `mapstructure:"sendTime"`
+		ExpireAt                   int64  `mapstructure:"expireAt"`
+	}
+
+	// ChatResult 表示发送聊天消息的结果。
+	//
+	// 该结构体包含服务端为消息分配的ID、会话ID及发送时间，客户端据此确认消息已发送并用于标记已读。
+	// ClientMsgId 为客户端生成的消息ID，重复发送的消息返回首次发送时的结果，Duplicate 为 true。
+	ChatResult struct {
+		ConversationId string `mapstructure:"conversationId"`
+		MsgId          string `mapstructure:"msgId"`
+		ClientMsgId    string `mapstructure:"clientMsgId"`
+		SendTime       int64  `mapstructure:"sendTime"`
+		Duplicate      bool   `mapstructure:"duplicate"`
+	}
 )
+
+// Chat 将推送转换为发送给客户端的聊天消息，在线推送及保存离线消息时使用相同的格式。
+func (p *Push) Chat() *Chat {
+	return &Chat{
+		ConversationId: p.ConversationId,
+		ChatType:       p.ChatType,
+		SendId:         p.SendId,
+		RecvId:         p.RecvId,
+		SendTime:       p.SendTime,
+		Msg: Msg{
+			ReadRecords: p.ReadRecords,
+			MsgId:       p.MsgId,
+			ClientMsgId: p.ClientMsgId,
+			Seq:         p.Seq,
+			MType:       p.MType,
+			Content:     p.Content,
+		},
+	}
+}
@@ -3,7 +3,6 @@ package group
 import (
 	"context"
 	"im-chat/easy-chat/apps/social/rpc/socialclient"
-	"im-chat/easy-chat/pkg/constants"
 
 	"im-chat/easy-chat/apps/social/api/internal/svc"
 	"im-chat/easy-chat/apps/social/api/internal/types"
@@ -29,7 +28,7 @@ func NewGroupUserOnlineLogic(ctx context.Context, svcCtx *svc.ServiceContext) *G
 //
 // 功能描述:
 //   - 获取指定群组的所有成员
-//   - 检查这些成员是否在线，依据缓存中的在线用户信息
+//   - 批量查询这些成员的在线状态
 //   - 返回每个成员的在线状态
 //
 // 参数:
@@ -59,24 +58,13 @@ func (l *GroupUserOnlineLogic) GroupUserOnline(req *types.GroupUserOnlineReq) (r
 		uids = append(uids, groupUser.UserId)
 	}
 
-	// 查询缓存中所有在线用户的状态
-	onlines, err := l.svcCtx.Redis.Hgetall(constants.REDIS_ONLINE_USER)
+	// 批量查询群组成员的在线状态
+	resOnLineList, err := l.svcCtx.Presence.OnlineMap(l.ctx, uids...)
 	if err != nil {
-		// 如果查询缓存失败，则返回空响应和错误
+		// 如果查询在线状态失败，则返回空响应和错误
 		return nil, err
 	}
 
-	// 创建一个映射，用于存储每个用户的在线状态
-	resOnLineList := make(map[string]bool, len(uids))
-	for _, s := range uids {
-		// 如果用户ID在缓存中，则表示该用户在线，否则为离线
-		if _, ok := onlines[s]; ok {
-			resOnLineList[s] = true
-		} else {
-			resOnLineList[s] = false
-		}
-	}
-
 	// 返回群组用户在线状态的响应
 	return &types.GroupUserOnlineResp{
 		OnlineList: resOnLineList, // 在线用户状态映射
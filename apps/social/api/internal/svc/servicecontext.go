@@ -10,6 +10,7 @@ import (
 	"im-chat/easy-chat/apps/user/rpc/userclient"
 	"im-chat/easy-chat/pkg/interceptor"
 	"im-chat/easy-chat/pkg/middleware"
+	"im-chat/easy-chat/pkg/presence"
 )
 
 type ServiceContext struct {
@@ -25,17 +26,22 @@ type ServiceContext struct {
 	imclient.Im
 
 	*redis.Redis
+
+	// 用户的在线状态，由 im.ws 维护
+	Presence *presence.Presence
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
+	rds := redis.MustNewRedis(c.Redisx)
 	return &ServiceContext{
 		Config:                c,
 		IdempotenceMiddleware: middleware.NewIdempotenceMiddleware().Handler,
 		LimitMiddleware:       middleware.NewLimitMiddleware(c.Redisx).TokenLimitHandler(1, 100),
 		Social: socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc,
 			zrpc.WithUnaryClientInterceptor(interceptor.DefaultIdempotentClient))),
-		User:  userclient.NewUser(zrpc.MustNewClient(c.UserRpc)),
-		Im:    imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
-		Redis: redis.MustNewRedis(c.Redisx),
+		User:     userclient.NewUser(zrpc.MustNewClient(c.UserRpc)),
+		Im:       imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
+		Redis:    rds,
+		Presence: presence.NewPresence(rds, 0),
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/zrpc"
+	"time"
 )
 
 type Config struct {
@@ -14,8 +15,25 @@ type Config struct {
 	MsgChatTransfer kq.KqConf
 	MsgReadTransfer kq.KqConf
 
+	// 每个队列处理消息的工作协程数，同一会话的消息由同一个协程按顺序处理
+	ConsumeWorkers int `json:",default=8"`
+
+	// 消息处理失败时的重试策略，Nums 为最多处理的次数，重试间隔从 Backoff 开始翻倍，最长为 MaxBackoff，
+	// Timeout 为每次处理的超时时间
+	Retry struct {
+		Nums       int           `json:",default=3"`
+		Timeout    time.Duration `json:",default=10s"`
+		Backoff    time.Duration `json:",default=200ms"`
+		MaxBackoff time.Duration `json:",default=5s"`
+	}
+	// 多次重试仍处理失败的消息写入死信队列，通过 -replay 重新投递到原队列
+	DeadLetter kq.KqConf
+
 	SocialRpc zrpc.RpcClientConf
 
+	// im.ws 使用 wss 时开启
+	WsTLS bool `json:",optional"`
+
 	Redisx redis.RedisConf
 	Mongo  struct {
 		Url string
@@ -27,8 +45,4 @@ type Config struct {
 		GroupMsgReadRecordDelayTime  int64
 		GroupMsgReadRecordDelayCount int
 	}
-
-	Ws struct {
-		Host string
-	}
 }
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/core/service"
 	"im-chat/easy-chat/apps/task/mq/internal/handler/msgTransfer"
 	"im-chat/easy-chat/apps/task/mq/internal/svc"
@@ -15,10 +14,23 @@ func NewListen(svc *svc.ServiceContext) *Listen {
 	return &Listen{svc: svc}
 }
 
+// Services 返回 task.mq 的消费者。
+//
+// 生产者以会话ID作为消息的 key，同一会话的消息写入同一个分区，分区内的顺序即会话内消息的顺序；
+// 每个队列由 OrderedConsumer 使用单个协程按分区的顺序拉取，按会话在 ConsumeWorkers 个协程上并行处理，
+// 处理完成后才提交位移。kq 配置中的 Consumers、Processors 对这两个队列不生效。
+// 已读回执由 MsgReadTransfer 异步推送，放在消费者之后，停止时先停止消费者再停止推送。
 func (l *Listen) Services() []service.Service {
+	c := l.svc.Config
+	readTransfer := msgTransfer.NewMsgReadTransfer(l.svc)
 	return []service.Service{
 		// todo: 此处可以加载多个消费者
-		kq.MustNewQueue(l.svc.Config.MsgReadTransfer, msgTransfer.NewMsgReadTransfer(l.svc)),
-		kq.MustNewQueue(l.svc.Config.MsgChatTransfer, msgTransfer.NewMsgChatTransfer(l.svc)),
+		msgTransfer.MustNewOrderedConsumer(c.MsgReadTransfer,
+			msgTransfer.NewRetryConsumer(l.svc, c.MsgReadTransfer.Topic, readTransfer),
+			c.ConsumeWorkers),
+		msgTransfer.MustNewOrderedConsumer(c.MsgChatTransfer,
+			msgTransfer.NewRetryConsumer(l.svc, c.MsgChatTransfer.Topic, msgTransfer.NewMsgChatTransfer(l.svc)),
+			c.ConsumeWorkers),
+		readTransfer,
 	}
 }
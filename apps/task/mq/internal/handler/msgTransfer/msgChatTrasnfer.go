@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"im-chat/easy-chat/apps/im/immodels"
 	"im-chat/easy-chat/apps/im/ws/ws"
 	"im-chat/easy-chat/apps/task/mq/internal/svc"
@@ -37,6 +38,11 @@ func NewMsgChatTransfer(svc *svc.ServiceContext) *MsgChatTransfer {
 // Consume 处理从消息队列中消费的聊天消息。
 //
 // 该方法从消息队列中获取的数据进行反序列化、记录日志，并将消息转发给目标用户。
+// 每条消息在写入前从会话中原子地分配递增的序号，随推送下发，客户端据此发现缺失的消息并排序。
+// 会话记录最近一次分配序号的消息ID，写入聊天记录失败后重试同一条消息时复用已分配的序号；
+// 只有消息最终写入死信队列且之后会话中有新的消息时，序号才会出现空洞。
+// 重复投递、重试及从死信队列重新投递的消息使用相同的服务端消息ID，聊天记录已存在时不再分配序号，
+// 按已保存的序号更新会话（同一条消息不会重复计数）并重新推送，客户端按消息ID或序号丢弃重复的消息。
 //
 // 参数:
 //   - key: 消息队列中的键值，通常用于标识消息。
@@ -50,14 +56,34 @@ func (m *MsgChatTransfer) Consume(ctx context.Context, key, value string) error
 	var (
 		data mq.MsgChatTransfer
 		//ctx  = context.Background()
-		msgID = primitive.NewObjectID()
 	)
 	if err := json.Unmarshal([]byte(value), &data); err != nil {
 		return err
 	}
 
-	// 记录数据
-	if err := m.addChatLog(ctx, msgID, &data); err != nil {
+	// 使用网关分配的消息ID，与返回给发送者的ID一致
+	msgID, err := primitive.ObjectIDFromHex(data.ServerMsgId)
+	if err != nil {
+		msgID = primitive.NewObjectID()
+	}
+
+	// 重复投递的消息已经分配过序号，不再重复分配
+	chatLog, err := m.svcCtx.ChatLogModel.FindOne(ctx, msgID.Hex())
+	switch err {
+	case nil:
+		m.Infof("duplicate chat msg %v client msg %v sendId %v seq %v", msgID.Hex(), data.MsgId, data.SendId, chatLog.Seq)
+	case immodels.ErrNotFound:
+		// 记录数据
+		chatLog, err = m.addChatLog(ctx, msgID, &data)
+		if err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	// 上次处理时可能在更新会话前失败
+	if err := m.svcCtx.ConversationModel.UpdateMsg(ctx, chatLog); err != nil {
 		return err
 	}
 
@@ -65,37 +91,52 @@ func (m *MsgChatTransfer) Consume(ctx context.Context, key, value string) error
 		ConversationId: data.ConversationId,
 		ChatType:       data.ChatType,
 		SendId:         data.SendId,
+		SendDeviceId:   data.SendDeviceId,
 		RecvId:         data.RecvId,
 		RecvIds:        data.RecvIds,
 		SendTime:       data.SendTime,
 		MType:          data.MType,
-		MsgId:          data.MsgId,
+		MsgId:          msgID.Hex(),
+		ClientMsgId:    data.MsgId,
+		Seq:            chatLog.Seq,
 		Content:        data.Content,
 	})
 }
 
-func (m *MsgChatTransfer) addChatLog(ctx context.Context, msgId primitive.ObjectID, data *mq.MsgChatTransfer) error {
+// addChatLog 为消息分配序号并写入聊天记录，聊天记录已存在时返回已保存的记录。
+func (m *MsgChatTransfer) addChatLog(ctx context.Context, msgId primitive.ObjectID, data *mq.MsgChatTransfer) (*immodels.ChatLog, error) {
+	// 分配会话内的消息序号，重试同一条消息时复用已分配的序号
+	seq, err := m.svcCtx.ConversationModel.IncrSeq(ctx, data.ConversationId, data.ChatType, msgId.Hex())
+	if err != nil {
+		return nil, err
+	}
+
 	// 记录消息
 	chatLog := immodels.ChatLog{
 		ID:             msgId,
 		ConversationId: data.ConversationId,
 		SendId:         data.SendId,
+		ClientMsgId:    data.MsgId,
 		RecvId:         data.RecvId,
 		ChatType:       data.ChatType,
 		MsgFrom:        0,
 		MsgType:        data.MType,
 		MsgContent:     data.Content,
 		SendTime:       data.SendTime,
+		Seq:            seq,
 	}
 
 	readRecords := bitmap.NewBitmap(0)
 	readRecords.Set(chatLog.SendId)
 	chatLog.ReadRecords = readRecords.Export()
 
-	err := m.svcCtx.ChatLogModel.Insert(ctx, &chatLog)
-	if err != nil {
-		return err
+	if err := m.svcCtx.ChatLogModel.Insert(ctx, &chatLog); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+		// 同一条消息已被写入，使用已保存的序号
+		m.Infof("duplicate chat msg %v client msg %v sendId %v", msgId.Hex(), data.MsgId, data.SendId)
+		return m.svcCtx.ChatLogModel.FindOne(ctx, msgId.Hex())
 	}
-
-	return m.svcCtx.ConversationModel.UpdateMsg(ctx, &chatLog)
+	return &chatLog, nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"im-chat/easy-chat/apps/im/ws/ws"
 	"im-chat/easy-chat/apps/task/mq/internal/svc"
@@ -25,9 +24,15 @@ const (
 	GroupMsgReadHandlerDelayTransfer
 )
 
+// MsgReadTransfer 处理已读消息，更新聊天记录的已读记录后异步推送已读回执。
+//
+// MsgReadTransfer 实现了 service.Service，Start 推送已读回执，Stop 后正在推送的回执被取消。
 type MsgReadTransfer struct {
 	*baseMsgTransfer
 
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	cache.Cache
 
 	mu sync.RWMutex
@@ -36,10 +41,12 @@ type MsgReadTransfer struct {
 	push      chan *ws.Push
 }
 
-func NewMsgReadTransfer(svc *svc.ServiceContext) kq.ConsumeHandler {
-
+func NewMsgReadTransfer(svc *svc.ServiceContext) *MsgReadTransfer {
+	ctx, cancel := context.WithCancel(context.Background())
 	m := &MsgReadTransfer{
 		baseMsgTransfer: NewMsgTransfer(svc),
+		ctx:             ctx,
+		cancel:          cancel,
 		groupMsgs:       make(map[string]*groupMsgRead, 1),
 		push:            make(chan *ws.Push, 1),
 	}
@@ -53,10 +60,31 @@ func NewMsgReadTransfer(svc *svc.ServiceContext) kq.ConsumeHandler {
 		}
 	}
 
-	go m.transfer()
 	return m
 }
 
+// Start 推送已读回执，阻塞直到调用 Stop。
+func (m *MsgReadTransfer) Start() {
+	m.transfer()
+}
+
+// Stop 停止推送已读回执，正在推送的回执被取消。
+func (m *MsgReadTransfer) Stop() {
+	m.cancel()
+}
+
+// send 将已读回执交给推送协程，ctx 结束或停止推送时返回错误。
+func (m *MsgReadTransfer) send(ctx context.Context, push *ws.Push) error {
+	select {
+	case m.push <- push:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-m.ctx.Done():
+		return m.ctx.Err()
+	}
+}
+
 func (m *MsgReadTransfer) Consume(ctx context.Context, key, value string) error {
 	m.Info("MsgReadTransfer", value)
 
@@ -85,11 +113,13 @@ func (m *MsgReadTransfer) Consume(ctx context.Context, key, value string) error
 	switch data.ChatType {
 	case constants.SingleChatType:
 		//直接推送
-		m.push <- push
+		return m.send(ctx, push)
 	case constants.GroupChatType:
 		//判断是否开启合并消息的处理
 		if m.svcCtx.Config.MsgReadHandler.GroupMsgReadHandler == GroupMsgReadHandlerAtTransfer {
-			m.push <- push
+			if err := m.send(ctx, push); err != nil {
+				return err
+			}
 		}
 		m.mu.Lock()
 		defer m.mu.Unlock()
@@ -139,13 +169,23 @@ func (m *MsgReadTransfer) UpdateChatLogRead(ctx context.Context, data *mq.MsgMar
 
 }
 
-// 异步处理消息发送
+// 异步处理消息发送，停止推送后返回
 func (m *MsgReadTransfer) transfer() {
-	for push := range m.push {
+	for {
+		var push *ws.Push
+		select {
+		case push = <-m.push:
+		case <-m.ctx.Done():
+			return
+		}
+
 		if push.RecvId != "" || len(push.RecvIds) > 0 {
-			if err := m.Transfer(context.Background(), push); err != nil {
+			// 异步推送不经过重试，等待写入 im.ws 的时间不超过一次重试的超时时间
+			ctx, cancel := context.WithTimeout(m.ctx, m.svcCtx.Config.Retry.Timeout)
+			if err := m.Transfer(ctx, push); err != nil {
 				m.Errorf("transfer err: %s", err.Error())
 			}
+			cancel()
 		}
 		if push.ChatType == constants.SingleChatType {
 			continue
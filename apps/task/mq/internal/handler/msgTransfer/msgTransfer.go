@@ -36,12 +36,7 @@ func (m *baseMsgTransfer) Transfer(ctx context.Context, data *ws.Push) error {
 
 // 私聊
 func (m *baseMsgTransfer) single(ctx context.Context, data *ws.Push) error {
-	return m.svcCtx.WsClient.Send(websocket.Message{
-		FrameType: websocket.FrameData,
-		Method:    "push",
-		FormId:    constants.SYSTEM_ROOT_UID,
-		Data:      data,
-	})
+	return m.dispatch(ctx, []string{data.RecvId}, data)
 }
 
 // 群聊
@@ -64,10 +59,75 @@ func (m *baseMsgTransfer) group(ctx context.Context, data *ws.Push) error {
 	}
 
 	// 推送消息
-	return m.svcCtx.WsClient.Send(websocket.Message{
-		FrameType: websocket.FrameData,
-		Method:    "push",
-		FormId:    constants.SYSTEM_ROOT_UID,
-		Data:      data,
+	return m.dispatch(ctx, data.RecvIds, data)
+}
+
+// dispatch 将消息推送到接收者所在的 im.ws 节点。
+//
+// 需要同步到发送者其他设备的消息，同时会推送到发送者所在的节点；每个节点的 RecvIds 只携带该节点上的接收者，
+// 私聊时接收者不在该节点上则为空，该节点只做多端同步。
+// 不在任何节点上的接收者保存为离线消息，任意设备连接后推送，已读回执不保存。
+// 等待消息写入节点的连接后返回，节点断线等导致消息未能发出时返回错误，由调用方重试。
+func (m *baseMsgTransfer) dispatch(ctx context.Context, recvIds []string, data *ws.Push) error {
+	uids := make([]string, 0, len(recvIds)+1)
+	uids = append(uids, recvIds...)
+	if data.SendDeviceId != "" {
+		uids = append(uids, data.SendId)
+	}
+
+	return m.svcCtx.Dispatcher.DispatchCtx(ctx, uids, func(node string, nodeUids []string) any {
+		push := *data
+		switch data.ChatType {
+		case constants.SingleChatType:
+			push.RecvIds = filterRecvIds([]string{data.RecvId}, nodeUids)
+		case constants.GroupChatType:
+			push.RecvIds = filterRecvIds(data.RecvIds, nodeUids)
+		}
+
+		return websocket.Message{
+			FrameType: websocket.FrameData,
+			Method:    "push",
+			FormId:    constants.SYSTEM_ROOT_UID,
+			Data:      &push,
+		}
+	}, func(offlineUids []string) error {
+		return m.saveOffline(offlineUids, data)
 	})
 }
+
+// saveOffline 将消息保存为离线接收者的离线消息，发送者本人不在线时无需同步，已读回执不保存。
+//
+// 离线消息与在线推送的格式相同，客户端按 push 路由处理。
+func (m *baseMsgTransfer) saveOffline(uids []string, data *ws.Push) error {
+	if data.ContentType == constants.ContentMarkRead {
+		return nil
+	}
+
+	msg := websocket.NewMessage(data.SendId, data.Chat())
+	msg.Method = "push"
+	for _, uid := range uids {
+		if uid == data.SendId {
+			continue
+		}
+		if err := m.svcCtx.OfflineStore.Save(uid, websocket.OfflineAnyDevice, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterRecvIds 获取在指定节点上的接收者
+func filterRecvIds(recvIds, nodeUids []string) []string {
+	set := make(map[string]struct{}, len(nodeUids))
+	for _, uid := range nodeUids {
+		set[uid] = struct{}{}
+	}
+
+	res := make([]string, 0, len(nodeUids))
+	for _, uid := range recvIds {
+		if _, ok := set[uid]; ok {
+			res = append(res, uid)
+		}
+	}
+	return res
+}
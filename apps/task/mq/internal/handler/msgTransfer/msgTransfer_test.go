@@ -0,0 +1,137 @@
+package msgTransfer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"im-chat/easy-chat/apps/im/ws/websocket"
+	"im-chat/easy-chat/apps/im/ws/ws"
+	"im-chat/easy-chat/apps/task/mq/internal/svc"
+	"im-chat/easy-chat/pkg/constants"
+)
+
+// testAuthentication 使用 query 参数或请求头 X-User-Id 作为用户标识
+type testAuthentication struct{}
+
+func (a *testAuthentication) Auth(w http.ResponseWriter, r *http.Request) bool {
+	return a.UserId(r) != ""
+}
+
+func (*testAuthentication) UserId(r *http.Request) string {
+	if uid := r.URL.Query().Get("userId"); uid != "" {
+		return uid
+	}
+	return r.Header.Get("X-User-Id")
+}
+
+// nodePush 节点收到的推送
+type nodePush struct {
+	node    string
+	recvIds []string
+}
+
+// newTestNode 启动一个 im.ws 节点，记录收到的推送，并以 uid 的 deviceId 设备连接到该节点
+func newTestNode(t *testing.T, locator websocket.Locator, pushes chan<- nodePush, uid, deviceId string) {
+	t.Helper()
+
+	var srv *websocket.Server
+	hs := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.ServerWs(w, r)
+	}))
+	node := hs.Listener.Addr().String()
+	srv = websocket.NewServer(node,
+		websocket.WithServerAuthentication(new(testAuthentication)),
+		websocket.WithServerLocator(locator, ""),
+		websocket.WithServerInternalUsers(constants.SYSTEM_ROOT_UID),
+	)
+	srv.AddRoutes([]websocket.Route{{Method: "push", Handler: func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Push
+		if err := conn.Bind(msg, &data); err != nil {
+			t.Errorf("bind push err %v", err)
+			return
+		}
+		pushes <- nodePush{node: node, recvIds: data.RecvIds}
+	}}})
+	hs.Start()
+	t.Cleanup(hs.Close)
+
+	u := "ws" + strings.TrimPrefix(hs.URL, "http") + "?userId=" + uid + "&deviceId=" + deviceId
+	conn, _, err := gorilla.DefaultDialer.Dial(u, nil)
+	if err != nil {
+		t.Fatalf("dial err %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for srv.GetConn(uid) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("wait for %v connect timeout", uid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMsgTransfer_SingleTwoNodes(t *testing.T) {
+	locator := websocket.NewMemoryLocator()
+	pushes := make(chan nodePush, 2)
+
+	// 发送者的另一台设备与接收者在不同的节点上
+	newTestNode(t, locator, pushes, "u1", "pc")
+	newTestNode(t, locator, pushes, "u2", "phone")
+
+	header := http.Header{}
+	header.Set("X-User-Id", constants.SYSTEM_ROOT_UID)
+	dispatcher := websocket.NewDispatcher(locator, websocket.WithClientHeader(header))
+	defer dispatcher.Close()
+
+	store := websocket.NewMemoryOfflineStore()
+	m := NewMsgTransfer(&svc.ServiceContext{Dispatcher: dispatcher, OfflineStore: store})
+
+	err := m.Transfer(context.Background(), &ws.Push{
+		ConversationId: "u1_u2",
+		ChatType:       constants.SingleChatType,
+		SendId:         "u1",
+		SendDeviceId:   "phone",
+		RecvId:         "u2",
+		MsgId:          "m1",
+		Content:        "hello",
+	})
+	if err != nil {
+		t.Fatalf("Transfer() err %v", err)
+	}
+
+	recvIds := make(map[string][]string, 2)
+	for i := 0; i < 2; i++ {
+		select {
+		case p := <-pushes:
+			recvIds[p.node] = p.recvIds
+		case <-time.After(2 * time.Second):
+			t.Fatalf("wait for push timeout, got %v", recvIds)
+		}
+	}
+
+	// 接收者所在的节点推送给接收者，发送者所在的节点只做多端同步
+	nodes, _ := locator.Locate("u1", "u2")
+	if len(nodes) != 2 {
+		t.Fatalf("nodes = %v, want 2", nodes)
+	}
+	for node, uids := range nodes {
+		want := 0
+		if uids[0] == "u2" {
+			want = 1
+		}
+		if got := recvIds[node]; len(got) != want || (want == 1 && got[0] != "u2") {
+			t.Errorf("node of %v recvIds = %v", uids, got)
+		}
+	}
+
+	// 接收者在线，不保存离线消息
+	if msgs, _ := store.Load("u2", websocket.OfflineAnyDevice); len(msgs) != 0 {
+		t.Errorf("offline messages = %v, want none", msgs)
+	}
+}
@@ -0,0 +1,321 @@
+package msgTransfer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	defaultOrderedWorkers = 8
+	defaultOrderedBuffer  = 64
+
+	// 处理失败（包括写入死信队列失败）时重新处理的间隔
+	orderedRetryInterval = time.Second
+	// 批量提交位移的间隔，与 kq 的默认值相同
+	orderedCommitInterval = time.Second
+)
+
+// kafkaReader 拉取消息及提交位移，由 *kafka.Reader 实现。
+type kafkaReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type orderedMsg struct {
+	kafka.Message
+	// 处理完成或停止时跳过后关闭
+	done chan struct{}
+	// 停止时尚未处理完成，不提交位移
+	skipped bool
+}
+
+// OrderedConsumer 按消息的 key 将消息分配给固定的工作协程处理，处理完成后按拉取的顺序提交位移。
+//
+// 生产者以会话ID作为消息的 key，同一会话的消息写入同一个分区，由单个协程按分区的顺序拉取，
+// 再交给 key 对应的工作协程按顺序处理，不同会话的消息在多个工作协程上并行处理。
+// 位移按拉取的顺序提交，只有一条消息及之前拉取的消息都处理完成后才提交，进程崩溃时未处理完成的消息会重新投递。
+// 处理失败的消息在当前工作协程中重新处理，不会跳过，之后的位移也不会提交。
+//
+// kq 的 Queue 处理完一条消息即提交位移，多个 Processor 并行时会越过仍在处理的消息提交，因此 OrderedConsumer
+// 直接使用 kafka-go 拉取消息。OrderedConsumer 实现了 service.Service，停止时取消正在处理的消息的上下文，
+// 并等待处理函数返回。
+type OrderedConsumer struct {
+	c       kq.KqConf
+	handler kq.ConsumeHandler
+	reader  kafkaReader
+
+	workers []chan *orderedMsg
+	commits chan *orderedMsg
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+	done   chan struct{}
+
+	logx.Logger
+}
+
+// NewOrderedConsumer 创建按 key 保序的消费者。
+//
+// 参数:
+//   - c: 消费的队列配置，使用其中的 Brokers、Group、Topic、Offset 及认证配置。
+//   - handler: 实际处理消息的消费者。
+//   - workers: 工作协程数，小于等于 0 时使用默认值。
+//
+// 返回值:
+//   - *OrderedConsumer: 新创建的消费者，调用 Start 后开始消费。
+//   - error: 配置不完整或读取 CaFile 失败时返回错误。
+func NewOrderedConsumer(c kq.KqConf, handler kq.ConsumeHandler, workers int) (*OrderedConsumer, error) {
+	reader, err := newReader(c)
+	if err != nil {
+		return nil, err
+	}
+	return newOrderedConsumer(c, reader, handler, workers), nil
+}
+
+func newOrderedConsumer(c kq.KqConf, reader kafkaReader, handler kq.ConsumeHandler, workers int) *OrderedConsumer {
+	if workers <= 0 {
+		workers = defaultOrderedWorkers
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	o := &OrderedConsumer{
+		c:       c,
+		handler: handler,
+		reader:  reader,
+		workers: make([]chan *orderedMsg, workers),
+		commits: make(chan *orderedMsg, workers*defaultOrderedBuffer),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		Logger:  logx.WithContext(context.Background()),
+	}
+	for i := range o.workers {
+		o.workers[i] = make(chan *orderedMsg, defaultOrderedBuffer)
+	}
+	return o
+}
+
+// MustNewOrderedConsumer 与 NewOrderedConsumer 相同，出错时 panic。
+func MustNewOrderedConsumer(c kq.KqConf, handler kq.ConsumeHandler, workers int) *OrderedConsumer {
+	o, err := NewOrderedConsumer(c, handler, workers)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// Start 开始消费，阻塞直到调用 Stop。
+func (o *OrderedConsumer) Start() {
+	defer close(o.done)
+
+	workers := threading.NewRoutineGroup()
+	for _, msgs := range o.workers {
+		workers.RunSafe(func() {
+			o.work(msgs)
+		})
+	}
+	committer := threading.NewRoutineGroup()
+	committer.RunSafe(o.commit)
+
+	o.fetch()
+
+	// fetch 是唯一的发送方，退出后关闭队列，等待工作协程处理完正在处理的消息，再提交已完成的位移
+	for _, msgs := range o.workers {
+		close(msgs)
+	}
+	workers.Wait()
+	close(o.commits)
+	committer.Wait()
+
+	if err := o.reader.Close(); err != nil {
+		o.Errorf("close reader topic %v err %v", o.c.Topic, err)
+	}
+	logx.Infof("ordered consumer %v is closed", o.c.Topic)
+}
+
+// Stop 停止拉取消息，取消正在处理的消息的上下文并等待处理函数返回，提交已处理完成的位移；
+// 处理失败及队列中尚未处理的消息不提交，在重启后重新投递。
+func (o *OrderedConsumer) Stop() {
+	o.once.Do(o.cancel)
+	<-o.done
+}
+
+// fetch 按分区的顺序拉取消息，交给 key 对应的工作协程，并按拉取的顺序放入提交队列。
+func (o *OrderedConsumer) fetch() {
+	for {
+		msg, err := o.reader.FetchMessage(o.ctx)
+		if err != nil {
+			if o.ctx.Err() != nil {
+				return
+			}
+			o.Errorf("fetch message topic %v err %v", o.c.Topic, err)
+			continue
+		}
+
+		m := &orderedMsg{Message: msg, done: make(chan struct{})}
+		select {
+		case o.workers[o.index(string(msg.Key))] <- m:
+		case <-o.ctx.Done():
+			return
+		}
+		select {
+		case o.commits <- m:
+		case <-o.ctx.Done():
+			return
+		}
+	}
+}
+
+func (o *OrderedConsumer) work(msgs <-chan *orderedMsg) {
+	for m := range msgs {
+		o.consume(m)
+		close(m.done)
+	}
+}
+
+// consume 处理一条消息，失败时在当前协程中重新处理，直到成功或消费者停止。
+//
+// 处理函数的上下文在消费者停止时取消，停止后处理失败的消息不再重新处理，也不提交位移。
+func (o *OrderedConsumer) consume(m *orderedMsg) {
+	// 与 kq 相同，从消息头中恢复链路追踪的上下文
+	ctx := otel.GetTextMapPropagator().Extract(o.ctx, headerCarrier(m.Headers))
+	key, value := string(m.Key), string(m.Value)
+
+	for {
+		if o.ctx.Err() != nil {
+			m.skipped = true
+			return
+		}
+
+		err := o.handler.Consume(ctx, key, value)
+		if err == nil {
+			return
+		}
+		o.Errorf("consume topic %v partition %v offset %v key %v err %v", o.c.Topic, m.Partition, m.Offset, key, err)
+
+		select {
+		case <-time.After(orderedRetryInterval):
+		case <-o.ctx.Done():
+		}
+	}
+}
+
+// commit 按拉取的顺序等待消息处理完成后提交位移，遇到停止时跳过的消息后不再提交。
+//
+// Reader 按 CommitInterval 定期批量提交，同一分区只提交最后的位移。
+func (o *OrderedConsumer) commit() {
+	var skipped bool
+	for m := range o.commits {
+		if skipped {
+			continue
+		}
+
+		<-m.done
+		if m.skipped {
+			skipped = true
+			continue
+		}
+		if err := o.reader.CommitMessages(context.Background(), m.Message); err != nil {
+			o.Errorf("commit topic %v partition %v offset %v err %v", o.c.Topic, m.Partition, m.Offset, err)
+		}
+	}
+}
+
+func (o *OrderedConsumer) index(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(o.workers)))
+}
+
+// newReader 按 kq 的方式创建消费组的 Reader。
+func newReader(c kq.KqConf) (*kafka.Reader, error) {
+	if len(c.Brokers) == 0 || c.Group == "" || c.Topic == "" {
+		return nil, errors.New("kafka brokers, group and topic are required")
+	}
+
+	startOffset := kafka.LastOffset
+	if c.Offset == "first" {
+		startOffset = kafka.FirstOffset
+	}
+	readerConfig := kafka.ReaderConfig{
+		Brokers:        c.Brokers,
+		GroupID:        c.Group,
+		Topic:          c.Topic,
+		StartOffset:    startOffset,
+		MinBytes:       c.MinBytes,
+		MaxBytes:       c.MaxBytes,
+		MaxWait:        time.Second,
+		CommitInterval: orderedCommitInterval,
+	}
+
+	dialer := &kafka.Dialer{
+		Timeout:   10 * time.Second,
+		DualStack: true,
+	}
+	if len(c.Username) > 0 && len(c.Password) > 0 {
+		dialer.SASLMechanism = plain.Mechanism{
+			Username: c.Username,
+			Password: c.Password,
+		}
+		readerConfig.Dialer = dialer
+	}
+	if len(c.CaFile) > 0 {
+		caCert, err := os.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("invalid ca file %v", c.CaFile)
+		}
+		dialer.TLS = &tls.Config{
+			RootCAs:            caCertPool,
+			InsecureSkipVerify: true,
+		}
+		readerConfig.Dialer = dialer
+	}
+
+	return kafka.NewReader(readerConfig), nil
+}
+
+// headerCarrier 使用 kafka 的消息头传递链路追踪的上下文。
+type headerCarrier []kafka.Header
+
+var _ propagation.TextMapCarrier = headerCarrier(nil)
+
+func (h headerCarrier) Get(key string) string {
+	for _, header := range h {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// Set 只在拉取消息时读取，不需要写入。
+func (h headerCarrier) Set(string, string) {}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for _, header := range h {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
@@ -0,0 +1,177 @@
+package msgTransfer
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/zeromicro/go-queue/kq"
+)
+
+// testReader 依次返回 msgs 中的消息，之后阻塞直到 ctx 结束，并记录提交的位移
+type testReader struct {
+	msgs []kafka.Message
+
+	mu        sync.Mutex
+	fetched   int
+	committed []int64
+}
+
+func (r *testReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if r.fetched < len(r.msgs) {
+		msg := r.msgs[r.fetched]
+		r.fetched++
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *testReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (r *testReader) Close() error { return nil }
+
+func (r *testReader) commits() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.committed)
+}
+
+// waitFor 等待条件成立
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("wait for condition timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestOrderedConsumer_Commit(t *testing.T) {
+	reader := &testReader{msgs: []kafka.Message{
+		{Key: []byte("a"), Value: []byte("a1"), Offset: 0},
+		{Key: []byte("b"), Value: []byte("b1"), Offset: 1},
+		{Key: []byte("a"), Value: []byte("a2"), Offset: 2},
+		{Key: []byte("b"), Value: []byte("b2"), Offset: 3},
+	}}
+
+	var (
+		mu       sync.Mutex
+		consumed = make(map[string][]string)
+		failed   bool
+		release  = make(chan struct{})
+	)
+	handler := kq.WithHandle(func(ctx context.Context, key, value string) error {
+		if value == "b1" {
+			<-release
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		// a2 第一次处理失败，之后重新处理
+		if value == "a2" && !failed {
+			failed = true
+			return context.DeadlineExceeded
+		}
+		consumed[key] = append(consumed[key], value)
+		return nil
+	})
+
+	o := newOrderedConsumer(kq.KqConf{Topic: "test"}, reader, handler, 2)
+	go o.Start()
+	defer o.Stop()
+
+	// b1 未处理完成时，之后的消息即使处理完成也不提交
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(consumed["a"]) == 2
+	})
+	if got := reader.commits(); !slices.Equal(got, []int64{0}) {
+		t.Fatalf("commits = %v, want [0]", got)
+	}
+
+	close(release)
+	waitFor(t, func() bool { return len(reader.commits()) == 4 })
+	if got := reader.commits(); !slices.Equal(got, []int64{0, 1, 2, 3}) {
+		t.Errorf("commits = %v, want in fetch order", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(consumed["a"], []string{"a1", "a2"}) || !slices.Equal(consumed["b"], []string{"b1", "b2"}) {
+		t.Errorf("consumed = %v, want in key order", consumed)
+	}
+}
+
+func TestOrderedConsumer_Stop(t *testing.T) {
+	reader := &testReader{msgs: []kafka.Message{
+		{Key: []byte("a"), Value: []byte("a1"), Offset: 0},
+		{Key: []byte("a"), Value: []byte("a2"), Offset: 1},
+	}}
+
+	started := make(chan struct{})
+	handler := kq.WithHandle(func(ctx context.Context, key, value string) error {
+		if value == "a1" {
+			close(started)
+			time.Sleep(50 * time.Millisecond)
+		}
+		return nil
+	})
+
+	o := newOrderedConsumer(kq.KqConf{Topic: "test"}, reader, handler, 1)
+	go o.Start()
+	<-started
+	o.Stop()
+
+	// 正在处理的消息完成后提交，队列中尚未处理的消息不提交，重启后重新投递
+	if got := reader.commits(); !slices.Equal(got, []int64{0}) {
+		t.Errorf("commits = %v, want [0]", got)
+	}
+}
+
+func TestOrderedConsumer_StopCancel(t *testing.T) {
+	reader := &testReader{msgs: []kafka.Message{
+		{Key: []byte("a"), Value: []byte("a1"), Offset: 0},
+	}}
+
+	var attempts atomic.Int32
+	started := make(chan struct{})
+	handler := kq.WithHandle(func(ctx context.Context, key, value string) error {
+		if attempts.Add(1) == 1 {
+			close(started)
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	o := newOrderedConsumer(kq.KqConf{Topic: "test"}, reader, handler, 1)
+	go o.Start()
+	<-started
+	o.Stop()
+
+	// 停止时取消正在处理的消息，处理失败的消息不再重新处理，也不提交
+	if got := reader.commits(); len(got) != 0 {
+		t.Errorf("commits = %v, want none", got)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("attempts = %v, want 1", n)
+	}
+}
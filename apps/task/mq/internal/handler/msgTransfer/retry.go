@@ -0,0 +1,121 @@
+package msgTransfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
+	"im-chat/easy-chat/apps/task/mq/internal/svc"
+	"im-chat/easy-chat/apps/task/mq/mq"
+	"im-chat/easy-chat/pkg/job"
+)
+
+// RetryConsumer 为消费者增加失败重试及死信处理。
+//
+// 处理失败的消息按 Retry 配置以指数退避的间隔重试，Mongo、RPC 及 im.ws 的临时故障通常在重试后恢复；
+// 消息无法解析等不可恢复的错误不再重试。重试后仍失败的消息连同错误及尝试次数写入死信队列，
+// 之后可以通过 task -replay 重新投递到原队列。
+//
+// 每次处理都在调用者的协程中执行，使用超时为 Retry.Timeout 的上下文，处理函数需要在上下文结束后尽快返回；
+// 一次处理返回后才开始下一次重试，同一条消息不会被并发处理。
+type RetryConsumer struct {
+	svcCtx  *svc.ServiceContext
+	topic   string
+	handler kq.ConsumeHandler
+	backoff job.RetryJetLagFunc
+
+	logx.Logger
+}
+
+// NewRetryConsumer 创建带重试及死信处理的消费者。
+//
+// 参数:
+//   - svc: 服务上下文，提供重试配置及死信队列。
+//   - topic: 消费的队列主题，重新投递时写回该主题。
+//   - handler: 实际处理消息的消费者。
+//
+// 返回值:
+//   - *RetryConsumer: 包装后的消费者。
+func NewRetryConsumer(svc *svc.ServiceContext, topic string, handler kq.ConsumeHandler) *RetryConsumer {
+	return &RetryConsumer{
+		svcCtx:  svc,
+		topic:   topic,
+		handler: handler,
+		backoff: job.RetryJetLagBackoff(svc.Config.Retry.Backoff, svc.Config.Retry.MaxBackoff),
+		Logger:  logx.WithContext(context.Background()),
+	}
+}
+
+// Consume 处理消息，重试后仍失败时写入死信队列。
+//
+// ctx 结束（消费者停止）时不再重试，也不写入死信队列，返回 ctx 的错误，消息在重启后重新投递；
+// 除此之外只有写入死信队列也失败时才返回错误。
+func (r *RetryConsumer) Consume(ctx context.Context, key, value string) error {
+	var (
+		consumeErr error
+		attempts   int
+		backoff    time.Duration
+	)
+	for attempts < max(r.svcCtx.Config.Retry.Nums, 1) {
+		if attempts > 0 {
+			backoff = r.backoff(ctx, attempts, backoff)
+			r.Infof("retry topic %v key %v attempt %v after %v", r.topic, key, attempts+1, backoff)
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		attempts++
+		if consumeErr = r.consume(ctx, key, value); consumeErr == nil {
+			return nil
+		}
+		if !isRetry(ctx, consumeErr) {
+			break
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Errorf("consume topic %v key %v failed after %v attempts err %v, value %v", r.topic, key, attempts, consumeErr, value)
+
+	body, err := json.Marshal(&mq.DeadLetter{
+		Topic:    r.topic,
+		Key:      key,
+		Value:    value,
+		Error:    consumeErr.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	return r.svcCtx.DeadLetter.PushWithKey(ctx, key, string(body))
+}
+
+// consume 执行一次处理，超过 Retry.Timeout 后取消上下文，并等待处理函数返回。
+func (r *RetryConsumer) consume(ctx context.Context, key, value string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.svcCtx.Config.Retry.Timeout)
+	defer cancel()
+
+	return r.handler.Consume(ctx, key, value)
+}
+
+// isRetry 消息无法解析时重试也不会成功，直接写入死信队列；ctx 结束时不再重试。
+func isRetry(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var (
+		syntaxErr    *json.SyntaxError
+		unmarshalErr *json.UnmarshalTypeError
+	)
+	return !errors.As(err, &syntaxErr) && !errors.As(err, &unmarshalErr)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
+	"im-chat/easy-chat/apps/task/mq/internal/config"
+	"im-chat/easy-chat/apps/task/mq/mq"
+)
+
+// replayIdleTimeout 死信队列在该时长内没有新的消息时认为已读到末尾
+const replayIdleTimeout = 10 * time.Second
+
+// Replay 将死信队列中的消息重新投递到原队列。
+//
+// 使用 DeadLetter 配置的消费组读取死信队列，按原消息的 key 写回原队列并提交位移，
+// 与 mqclient 相同按 key 哈希选择分区，同一会话的消息仍写入同一个分区。
+// 读到队列末尾后返回，适合在修复故障后手动执行。原队列不是 MsgChatTransfer 或 MsgReadTransfer 的消息会被跳过。
+//
+// 参数:
+//   - c: task.mq 的配置。
+//
+// 返回值:
+//   - int: 重新投递的消息数。
+//   - error: 读取死信队列或投递失败时返回错误，之前已投递的消息不会重复投递。
+func Replay(c config.Config) (int, error) {
+	pushers := map[string]*kq.Pusher{
+		c.MsgChatTransfer.Topic: kq.NewPusher(c.MsgChatTransfer.Brokers, c.MsgChatTransfer.Topic,
+			kq.WithBalancer(&kafka.Hash{}), kq.WithSyncPush()),
+		c.MsgReadTransfer.Topic: kq.NewPusher(c.MsgReadTransfer.Brokers, c.MsgReadTransfer.Topic,
+			kq.WithBalancer(&kafka.Hash{}), kq.WithSyncPush()),
+	}
+	defer func() {
+		for _, pusher := range pushers {
+			pusher.Close()
+		}
+	}()
+
+	startOffset := kafka.LastOffset
+	if c.DeadLetter.Offset == "first" {
+		startOffset = kafka.FirstOffset
+	}
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     c.DeadLetter.Brokers,
+		GroupID:     c.DeadLetter.Group,
+		Topic:       c.DeadLetter.Topic,
+		StartOffset: startOffset,
+	})
+	defer reader.Close()
+
+	var replayed int
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), replayIdleTimeout)
+		msg, err := reader.FetchMessage(ctx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return replayed, nil
+		}
+		if err != nil {
+			return replayed, err
+		}
+
+		var data mq.DeadLetter
+		if err := json.Unmarshal(msg.Value, &data); err != nil {
+			logx.Errorf("replay unmarshal dead letter offset %v err %v", msg.Offset, err)
+		} else if pusher, ok := pushers[data.Topic]; !ok {
+			logx.Errorf("replay dead letter offset %v unknown topic %v", msg.Offset, data.Topic)
+		} else {
+			if err := pusher.PushWithKey(context.Background(), data.Key, data.Value); err != nil {
+				return replayed, err
+			}
+			logx.Infof("replay topic %v key %v attempts %v err %v", data.Topic, data.Key, data.Attempts, data.Error)
+			replayed++
+		}
+
+		if err := reader.CommitMessages(context.Background(), msg); err != nil {
+			return replayed, err
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package svc
 
 import (
+	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/zrpc"
 	"im-chat/easy-chat/apps/im/immodels"
@@ -14,7 +15,9 @@ import (
 type ServiceContext struct {
 	config.Config
 
-	WsClient websocket.Client
+	Dispatcher *websocket.Dispatcher
+	websocket.OfflineStore
+	DeadLetter *kq.Pusher
 	*redis.Redis
 
 	socialclient.Social
@@ -25,6 +28,7 @@ type ServiceContext struct {
 func NewServiceContext(c config.Config) *ServiceContext {
 	svc := &ServiceContext{
 		Config:            c,
+		DeadLetter:        kq.NewPusher(c.DeadLetter.Brokers, c.DeadLetter.Topic, kq.WithSyncPush()),
 		Redis:             redis.MustNewRedis(c.Redisx),
 		ChatLogModel:      immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
 		ConversationModel: immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
@@ -39,7 +43,12 @@ func NewServiceContext(c config.Config) *ServiceContext {
 
 	header := http.Header{}
 	header.Set("Authorization", token)
-	svc.WsClient = websocket.NewClient(c.Ws.Host, websocket.WithClientHeader(header))
+	opts := []websocket.DailOptions{websocket.WithClientHeader(header)}
+	if c.WsTLS {
+		opts = append(opts, websocket.WithClientTLS(nil))
+	}
+	svc.OfflineStore = websocket.NewRedisOfflineStore(svc.Redis, 0)
+	svc.Dispatcher = websocket.NewDispatcher(websocket.NewRedisLocator(svc.Redis, 0), opts...)
 	return svc
 }
 
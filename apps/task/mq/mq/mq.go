@@ -3,11 +3,15 @@ package mq
 import "im-chat/easy-chat/pkg/constants"
 
 type MsgChatTransfer struct {
+	// 客户端生成的消息ID，客户端重发时保持不变
 	MsgId string `json:"msg_id"`
+	// 服务端分配的消息ID，作为聊天记录的ID
+	ServerMsgId string `json:"serverMsgId"`
 
 	ConversationId     string `json:"conversationId"`
 	constants.ChatType `json:"chatType"`
 	SendId             string   `json:"sendId"`
+	SendDeviceId       string   `json:"sendDeviceId"`
 	RecvId             string   `json:"recvId"`
 	RecvIds            []string `json:"recvIds"`
 	SendTime           int64    `json:"sendTime"`
@@ -23,3 +27,15 @@ type MsgMarkRead struct {
 	ConversationId     string   `json:"conversationId"`
 	MsgIds             []string `json:"msgIds"`
 }
+
+// DeadLetter 多次重试仍处理失败的消息，写入死信队列后可以重新投递到原队列。
+type DeadLetter struct {
+	// 原队列的主题及消息
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+
+	Error    string `json:"error"`
+	Attempts int    `json:"attempts"`
+	FailedAt int64  `json:"failedAt"`
+}
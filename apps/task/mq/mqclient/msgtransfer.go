@@ -3,10 +3,19 @@ package mqclient
 import (
 	"context"
 	"encoding/json"
+	"github.com/segmentio/kafka-go"
 	"github.com/zeromicro/go-queue/kq"
 	"im-chat/easy-chat/apps/task/mq/mq"
 )
 
+// newPusher 创建按消息 key 选择分区的 Pusher。
+//
+// 消息以会话ID作为 key，同一会话的消息写入同一个分区，消费时保持发送的顺序；
+// kq 默认的 LeastBytes 会忽略 key，这里改为按 key 哈希。
+func newPusher(addr []string, topic string, opts ...kq.PushOption) *kq.Pusher {
+	return kq.NewPusher(addr, topic, append([]kq.PushOption{kq.WithBalancer(&kafka.Hash{})}, opts...)...)
+}
+
 // MsgChatTransferClient 提供发送聊天消息的方法。
 //
 // 该接口定义了发送聊天消息的操作。
@@ -39,13 +48,13 @@ type msgChatTransferClient struct {
 //   - MsgChatTransferClient: 初始化好的消息推送客户端实例。
 func NewMsgChatTransferClient(addr []string, topic string, opts ...kq.PushOption) MsgChatTransferClient {
 	return &msgChatTransferClient{
-		pusher: kq.NewPusher(addr, topic),
+		pusher: newPusher(addr, topic, opts...),
 	}
 }
 
 // Push 将聊天消息推送到消息队列中。
 //
-// 该方法将聊天消息序列化为 JSON 格式，并通过 pusher 推送到消息队列中。
+// 该方法将聊天消息序列化为 JSON 格式，并以会话ID作为 key 通过 pusher 推送到消息队列中。
 //
 // 参数:
 //   - msg: 包含聊天消息的结构体，该消息将被发送到消息队列中。
@@ -58,7 +67,7 @@ func (c *msgChatTransferClient) Push(msg *mq.MsgChatTransfer) error {
 		return err
 	}
 
-	return c.pusher.Push(context.Background(), string(body))
+	return c.pusher.PushWithKey(context.Background(), msg.ConversationId, string(body))
 }
 
 type MsgReadTransferClient interface {
@@ -71,7 +80,7 @@ type msgReadTransferClient struct {
 
 func NewmsgReadTransferClient(addr []string, topic string, opts ...kq.PushOption) MsgReadTransferClient {
 	return &msgReadTransferClient{
-		pusher: kq.NewPusher(addr, topic),
+		pusher: newPusher(addr, topic, opts...),
 	}
 }
 
@@ -81,5 +90,5 @@ func (c *msgReadTransferClient) Push(msg *mq.MsgMarkRead) error {
 		return err
 	}
 
-	return c.pusher.Push(context.Background(), string(body))
+	return c.pusher.PushWithKey(context.Background(), msg.ConversationId, string(body))
 }
@@ -10,7 +10,10 @@ import (
 	"im-chat/easy-chat/apps/task/mq/internal/svc"
 )
 
-var configFile = flag.String("f", "etc/dev/task.yaml", "the config file")
+var (
+	configFile = flag.String("f", "etc/dev/task.yaml", "the config file")
+	replay     = flag.Bool("replay", false, "replay the dead letter messages to their topics and exit")
+)
 
 func main() {
 	flag.Parse()
@@ -21,6 +24,16 @@ func main() {
 	if err := c.SetUp(); err != nil {
 		panic(err)
 	}
+
+	if *replay {
+		n, err := handler.Replay(c)
+		fmt.Printf("replayed %d dead letter messages\n", n)
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+
 	ctx := svc.NewServiceContext(c)
 	listen := handler.NewListen(ctx)
 
@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"im-chat/easy-chat/apps/user/rpc/user"
+	"im-chat/easy-chat/pkg/ctxdata"
+
+	"im-chat/easy-chat/apps/user/api/internal/svc"
+	"im-chat/easy-chat/apps/user/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type LogoutLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewLogoutLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LogoutLogic {
+	return &LogoutLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Logout 当前用户登出。
+//
+// 功能描述:
+//   - 从上下文中获取用户ID。
+//   - 调用 svcCtx 的 User.Logout 方法吊销该用户此前签发的所有令牌。
+//
+// 参数:
+//   - req: *types.LogoutReq
+//     请求参数，用户ID从上下文中获取.
+//
+// 返回值:
+//   - *types.LogoutResp: 空的响应对象。
+//   - error: 如果吊销令牌失败，则返回相应的错误信息。
+func (l *LogoutLogic) Logout(req *types.LogoutReq) (resp *types.LogoutResp, err error) {
+	uid := ctxdata.GetUId(l.ctx)
+
+	if _, err := l.svcCtx.User.Logout(l.ctx, &user.LogoutReq{
+		Id: uid,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &types.LogoutResp{}, nil
+}
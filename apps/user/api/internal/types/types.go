@@ -38,3 +38,9 @@ type UserInfoReq struct {
 type UserInfoResp struct {
 	Info User `json:"info"`
 }
+
+type LogoutReq struct {
+}
+
+type LogoutResp struct {
+}
@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"im-chat/easy-chat/pkg/constants"
+	"im-chat/easy-chat/pkg/xerr"
+
+	"im-chat/easy-chat/apps/user/rpc/internal/svc"
+	"im-chat/easy-chat/apps/user/rpc/user"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type LogoutLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewLogoutLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LogoutLogic {
+	return &LogoutLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Logout 用户登出，吊销用户此前签发的所有令牌。
+//
+// 功能描述:
+//   - 在 Redis 的 constants.REDIS_TOKEN_REVOKED 中记录吊销的时间（Unix 秒）。
+//   - im.ws 拒绝在此之前签发的令牌建立连接或刷新令牌，已建立的连接在刷新令牌时被关闭。
+//   - 重新登录获取的令牌不受影响。
+//
+// 参数:
+//   - in: 包含需要登出的用户ID的请求结构体。
+//
+// 返回值:
+//   - *user.LogoutResp: 空的响应结构体。
+//   - error: 如果记录吊销时间失败，则返回相应的错误信息。
+func (l *LogoutLogic) Logout(in *user.LogoutReq) (*user.LogoutResp, error) {
+	revokedAt := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := l.svcCtx.Redis.HsetCtx(l.ctx, constants.REDIS_TOKEN_REVOKED, in.Id, revokedAt); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "set token revoked err %v, req %v", err, in.Id)
+	}
+
+	return &user.LogoutResp{}, nil
+}
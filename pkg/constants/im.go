@@ -19,3 +19,15 @@ const (
 	ContentChatMsg ContentType = iota
 	ContentMarkRead
 )
+
+// BroadcastSegment 系统公告的投递范围
+type BroadcastSegment int
+
+const (
+	// BroadcastAll 所有用户
+	BroadcastAll BroadcastSegment = iota
+	// BroadcastGroup 指定群的成员
+	BroadcastGroup
+	// BroadcastVersion 使用指定客户端版本的用户
+	BroadcastVersion
+)
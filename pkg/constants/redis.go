@@ -2,5 +2,18 @@ package constants
 
 const (
 	REDIS_SYSTEM_ROOT_TOKEN string = "system:root:token"
-	REDIS_ONLINE_USER       string = "online:user"
+	// 用户设备的在线记录，key 为 online:user:{uid}，由 im.ws 维护
+	REDIS_ONLINE_USER string = "online:user"
+	// 用户的最后在线时间，hash 的 field 为用户 id，value 为时间（Unix 秒）
+	REDIS_LAST_SEEN string = "online:lastseen"
+	// 用户设置的在线状态（away、busy、invisible 等），未设置时为 online
+	REDIS_ONLINE_STATE string = "online:state"
+	// 最近一次通知给好友的在线状态，用于过滤重复的通知
+	REDIS_ONLINE_NOTIFIED string = "online:notified"
+	// 用户令牌的吊销时间，hash 的 field 为用户 id，value 为吊销时间（Unix 秒）
+	REDIS_TOKEN_REVOKED string = "token:revoked"
+	// 系统公告的投递统计，key 为 broadcast:stats:{id}，field 为 nodes、delivered、failed
+	REDIS_BROADCAST_STATS string = "broadcast:stats"
+	// 聊天消息的去重记录，key 为 chat:dedup:{sendId}:{clientMsgId}，value 为首次发送时分配的消息ID及发送时间
+	REDIS_CHAT_DEDUP string = "chat:dedup"
 )
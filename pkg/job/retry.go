@@ -17,6 +17,16 @@ func RetryJetLagAlways(ctx context.Context, retryCount int, lastTime time.Durati
 	return DefaultRetryJetLag
 }
 
+// RetryJetLagBackoff 返回按重试次数指数增长的重试间隔，从 base 开始每次翻倍，最长不超过 max
+func RetryJetLagBackoff(base, max time.Duration) RetryJetLagFunc {
+	return func(ctx context.Context, retryCount int, lastTime time.Duration) time.Duration {
+		if lastTime <= 0 {
+			return base
+		}
+		return min(lastTime*2, max)
+	}
+}
+
 // IsRetryFunc 定义是否进行重试的函数类型
 type IsRetryFunc func(ctx context.Context, retryCount int, err error) bool
 
@@ -76,3 +76,19 @@ func TestWithRetry(t *testing.T) {
 		})
 	}
 }
+
+// 测试RetryJetLagBackoff函数
+func TestRetryJetLagBackoff(t *testing.T) {
+	var (
+		jetLag = RetryJetLagBackoff(100*time.Millisecond, time.Second)
+		last   time.Duration
+		want   = []time.Duration{100, 200, 400, 800, 1000, 1000}
+	)
+
+	for i, w := range want {
+		// 重试间隔翻倍，最长不超过 max
+		if last = jetLag(context.Background(), i, last); last != w*time.Millisecond {
+			t.Errorf("RetryJetLagBackoff() retry %v = %v, want %v", i, last, w*time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// AdminMiddleware 管理接口的鉴权中间件，请求需要携带 Authorization: Bearer {token}。
+type AdminMiddleware struct {
+	token string
+}
+
+// NewAdminMiddleware 创建管理接口的鉴权中间件。
+// 参数token: 管理接口的令牌，为空时拒绝所有请求。
+// 返回值: 新创建的AdminMiddleware实例。
+func NewAdminMiddleware(token string) *AdminMiddleware {
+	return &AdminMiddleware{
+		token: token,
+	}
+}
+
+// Handler 校验请求携带的管理令牌，校验失败时返回HTTP状态码401。
+func (m *AdminMiddleware) Handler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || m.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,269 @@
+package presence
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"im-chat/easy-chat/pkg/constants"
+)
+
+// 默认在线记录的过期时间（秒），im.ws 需要在过期前刷新
+const DefaultTTL = 90
+
+// 用户的在线状态，online、away、busy、invisible 可以由用户设置，隐身的用户对其他用户显示为 offline
+const (
+	StateOnline    = "online"
+	StateAway      = "away"
+	StateBusy      = "busy"
+	StateInvisible = "invisible"
+	StateOffline   = "offline"
+)
+
+// ValidState 判断 state 是否为用户可以设置的在线状态。
+func ValidState(state string) bool {
+	switch state {
+	case StateOnline, StateAway, StateBusy, StateInvisible:
+		return true
+	default:
+		return false
+	}
+}
+
+// onlineScript 记录用户设备在线并清理已经过期的设备，同时更新用户的最后在线时间。
+//
+// 设备记录的值为 {node}|{过期时间}，异常退出的节点不会再刷新记录，过期后在查询时忽略、在下次写入时清理。
+var onlineScript = redis.NewScript(`
+local now = tonumber(ARGV[4])
+local fields = redis.call("HGETALL", KEYS[1])
+for i = 1, #fields, 2 do
+    local expireAt = tonumber(string.match(fields[i + 1], "|(%d+)$"))
+    if expireAt == nil or expireAt < now then
+        redis.call("HDEL", KEYS[1], fields[i])
+    end
+end
+redis.call("HSET", KEYS[1], ARGV[2], ARGV[3] .. "|" .. (now + tonumber(ARGV[5])))
+redis.call("EXPIRE", KEYS[1], ARGV[5])
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[4])
+return 1
+`)
+
+// offlineScript 仅当设备记录仍属于 node 节点时才删除，避免误删设备在其他节点上的新连接。
+var offlineScript = redis.NewScript(`
+local v = redis.call("HGET", KEYS[1], ARGV[2])
+if v and string.find(v, ARGV[3] .. "|", 1, true) == 1 then
+    redis.call("HDEL", KEYS[1], ARGV[2])
+    redis.call("HSET", KEYS[2], ARGV[1], ARGV[4])
+    return 1
+end
+return 0
+`)
+
+// notifyScript 记录最近一次通知的在线状态，与之前相同时返回 0。
+var notifyScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+    return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
+// Status 用户的在线状态。
+//
+// 隐身的用户 Online 为 false 且不返回在线的设备。
+//
+// 字段:
+//   - Online: 是否有设备在线。
+//   - State: 对其他用户显示的在线状态，离线及隐身时为 offline。
+//   - Devices: 在线的设备 ID 列表。
+//   - LastSeen: 最后在线的时间（Unix 秒），从未上线过时为 0。
+type Status struct {
+	Online   bool
+	State    string
+	Devices  []string
+	LastSeen int64
+}
+
+// Presence 基于 Redis 维护用户的在线状态。
+//
+// 每个用户对应一个 hash，key 为 online:user:{uid}，field 为设备 ID，value 为 {node}|{过期时间}；
+// 用户的最后在线时间记录在 online:lastseen 中，用户设置的在线状态记录在 online:state 中，field 均为用户 ID。
+type Presence struct {
+	*redis.Redis
+	ttl int
+}
+
+// NewPresence 创建一个基于 Redis 的 Presence。
+//
+// 参数:
+//   - rds: Redis 客户端。
+//   - ttl: 设备在线记录的过期时间（秒），小于等于 0 时使用 DefaultTTL。
+//
+// 返回:
+//   - *Presence: 用户在线状态的存储。
+func NewPresence(rds *redis.Redis, ttl int) *Presence {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Presence{
+		Redis: rds,
+		ttl:   ttl,
+	}
+}
+
+func onlineKey(uid string) string {
+	return constants.REDIS_ONLINE_USER + ":" + uid
+}
+
+// Online 记录用户的设备在 node 节点上在线，连接建立及保活时调用，刷新记录的过期时间。
+func (p *Presence) Online(uid, deviceId, node string) error {
+	_, err := p.ScriptRun(onlineScript, []string{onlineKey(uid), constants.REDIS_LAST_SEEN},
+		uid, deviceId, node, time.Now().Unix(), p.ttl)
+	return err
+}
+
+// Offline 记录用户的设备离线并更新最后在线时间，只有记录仍属于 node 节点时才会移除。
+func (p *Presence) Offline(uid, deviceId, node string) error {
+	_, err := p.ScriptRun(offlineScript, []string{onlineKey(uid), constants.REDIS_LAST_SEEN},
+		uid, deviceId, node, time.Now().Unix())
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
+// Lookup 批量查询用户的在线状态。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - uids: 需要查询的用户 ID 列表。
+//
+// 返回:
+//   - map[string]*Status: 用户 ID -> 在线状态，包含所有查询的用户。
+//   - error: 查询过程中发生的错误（如果有的话）。
+func (p *Presence) Lookup(ctx context.Context, uids ...string) (map[string]*Status, error) {
+	if len(uids) == 0 {
+		return map[string]*Status{}, nil
+	}
+
+	devices := make([]interface {
+		Result() (map[string]string, error)
+	}, len(uids))
+	var lastSeen, states interface {
+		Result() ([]any, error)
+	}
+	err := p.PipelinedCtx(ctx, func(pipe redis.Pipeliner) error {
+		for i, uid := range uids {
+			devices[i] = pipe.HGetAll(ctx, onlineKey(uid))
+		}
+		lastSeen = pipe.HMGet(ctx, constants.REDIS_LAST_SEEN, uids...)
+		states = pipe.HMGet(ctx, constants.REDIS_ONLINE_STATE, uids...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup presence err %v", err)
+	}
+
+	seen, err := lastSeen.Result()
+	if err != nil {
+		return nil, err
+	}
+	state, err := states.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	res := make(map[string]*Status, len(uids))
+	for i, uid := range uids {
+		values, err := devices[i].Result()
+		if err != nil {
+			return nil, err
+		}
+
+		status := &Status{State: StateOffline}
+		if v, ok := seen[i].(string); ok {
+			status.LastSeen, _ = strconv.ParseInt(v, 10, 64)
+		}
+		res[uid] = status
+
+		if v, _ := state[i].(string); v == StateInvisible {
+			continue
+		}
+		for deviceId, value := range values {
+			if expireAt(value) < now {
+				continue
+			}
+			status.Devices = append(status.Devices, deviceId)
+		}
+		if len(status.Devices) == 0 {
+			continue
+		}
+		status.Online = true
+		status.State = StateOnline
+		if v, ok := state[i].(string); ok && v != "" {
+			status.State = v
+		}
+	}
+	return res, nil
+}
+
+// OnlineMap 批量查询用户是否在线。
+//
+// 返回:
+//   - map[string]bool: 用户 ID -> 是否在线，包含所有查询的用户。
+//   - error: 查询过程中发生的错误（如果有的话）。
+func (p *Presence) OnlineMap(ctx context.Context, uids ...string) (map[string]bool, error) {
+	status, err := p.Lookup(ctx, uids...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool, len(status))
+	for uid, s := range status {
+		res[uid] = s.Online
+	}
+	return res, nil
+}
+
+// SetState 设置用户的在线状态，state 必须是 ValidState 允许的状态。
+func (p *Presence) SetState(ctx context.Context, uid, state string) error {
+	if !ValidState(state) {
+		return fmt.Errorf("invalid presence state %v", state)
+	}
+	if state == StateOnline {
+		_, err := p.HdelCtx(ctx, constants.REDIS_ONLINE_STATE, uid)
+		return err
+	}
+	return p.HsetCtx(ctx, constants.REDIS_ONLINE_STATE, uid, state)
+}
+
+// Notified 记录通知给其他用户的在线状态，用于过滤连接频繁断开重连时重复的通知。
+//
+// 返回:
+//   - bool: 状态与最近一次通知的不同，需要通知时返回 true。
+//   - error: 记录过程中发生的错误（如果有的话）。
+func (p *Presence) Notified(ctx context.Context, uid, state string) (bool, error) {
+	res, err := p.ScriptRunCtx(ctx, notifyScript, []string{constants.REDIS_ONLINE_NOTIFIED}, uid, state)
+	if err != nil {
+		return false, err
+	}
+	changed, _ := res.(int64)
+	return changed == 1, nil
+}
+
+// expireAt 解析设备记录的过期时间，格式错误时视为已过期。
+func expireAt(value string) int64 {
+	idx := strings.LastIndexByte(value, '|')
+	if idx < 0 {
+		return 0
+	}
+	v, err := strconv.ParseInt(value[idx+1:], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
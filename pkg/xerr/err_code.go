@@ -1,7 +1,10 @@
 package xerr
 
 const (
-	SERVER_COMMON_ERROR = 100001
-	REQUEST_PARAM_ERROR = 100002
-	DB_ERROR            = 100003
+	SERVER_COMMON_ERROR    = 100001
+	REQUEST_PARAM_ERROR    = 100002
+	DB_ERROR               = 100003
+	RATE_LIMIT_ERROR       = 100004
+	TOKEN_INVALID_ERROR    = 100005
+	METHOD_NOT_FOUND_ERROR = 100006
 )
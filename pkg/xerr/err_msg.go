@@ -1,9 +1,12 @@
 package xerr
 
 var codeText = map[int]string{
-	SERVER_COMMON_ERROR: "服务器异常，稍后再尝试",
-	REQUEST_PARAM_ERROR: "请求参数有误",
-	DB_ERROR:            "数据库繁忙，稍后再尝试",
+	SERVER_COMMON_ERROR:    "服务器异常，稍后再尝试",
+	REQUEST_PARAM_ERROR:    "请求参数有误",
+	DB_ERROR:               "数据库繁忙，稍后再尝试",
+	RATE_LIMIT_ERROR:       "请求过于频繁，请稍后再试",
+	TOKEN_INVALID_ERROR:    "身份认证已失效，请重新登录",
+	METHOD_NOT_FOUND_ERROR: "不存在执行的方法，请检查",
 }
 
 func ErrMsg(errcode int) string {